@@ -7,19 +7,27 @@ package cleanup
 
 import (
 	"context"
+	"dolittle.io/fleet-observer/entities"
 	"dolittle.io/fleet-observer/storage"
 	"github.com/rs/zerolog"
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	listerCoreV1 "k8s.io/client-go/listers/core/v1"
+	"strings"
 	"time"
 )
 
 type Instances struct {
+	cluster      string
+	unscoped     bool
 	deployments  storage.Deployments
 	environments storage.Environments
 	applications storage.Applications
-	pods         listerCoreV1.PodLister
+	pods         []listerCoreV1.PodLister
+	namespaces   listerCoreV1.NamespaceLister
+	observed     []string
+	restricted   bool
 	logger       zerolog.Logger
 }
 
@@ -29,7 +37,16 @@ func (i *Instances) Cleanup(ctx context.Context) error {
 		return err
 	}
 
-	existingPods, err := i.pods.List(labels.Everything())
+	var existingPods []*coreV1.Pod
+	for _, pods := range i.pods {
+		listed, err := pods.List(labels.Everything())
+		if err != nil {
+			return err
+		}
+		existingPods = append(existingPods, listed...)
+	}
+
+	observedApplications, err := i.getObservedApplicationPrefixes()
 	if err != nil {
 		return err
 	}
@@ -46,11 +63,29 @@ func (i *Instances) Cleanup(ctx context.Context) error {
 		return err
 	}
 
+	// the instances of other clusters are cleaned up by the observers of those clusters,
+	// and instances on nodes without a cluster prefix were stored before clusters were observed
+	clusterPrefix := string(entities.NewClusterUID(i.cluster)) + "/"
+
 	for _, instance := range runningInstances {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
 
+		node := string(instance.Links.ScheduledOnNodeUID)
+		if !strings.HasPrefix(node, clusterPrefix) && !(i.unscoped && !strings.Contains(node, "/")) {
+			continue
+		}
+
+		if i.restricted && !hasAnyPrefix(string(instance.UID), observedApplications) {
+			continue
+		}
+
+		// instances of jobs are stopped by the jobs observer, since the pods of a job can be retried
+		if instance.Properties.Status != "" {
+			continue
+		}
+
 		if instance.Properties.Stopped != nil {
 			i.logger.Warn().
 				Str("uid", string(instance.UID)).
@@ -76,3 +111,40 @@ func (i *Instances) Cleanup(ctx context.Context) error {
 
 	return nil
 }
+
+// getObservedApplicationPrefixes returns the UID prefixes of the applications in the observed namespaces,
+// so that instances in namespaces that are not observed are not marked as stopped
+func (i *Instances) getObservedApplicationPrefixes() ([]string, error) {
+	if !i.restricted {
+		return nil, nil
+	}
+
+	var prefixes []string
+	for _, name := range i.observed {
+		namespace, err := i.namespaces.Get(name)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tenantID, hasTenantID := namespace.GetAnnotations()["dolittle.io/tenant-id"]
+		applicationID, hasApplicationID := namespace.GetAnnotations()["dolittle.io/application-id"]
+		if !hasTenantID || !hasApplicationID {
+			continue
+		}
+
+		prefixes = append(prefixes, string(entities.NewApplicationUID(tenantID, applicationID))+"/")
+	}
+	return prefixes, nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -7,29 +7,39 @@ package cleanup
 
 import (
 	"context"
+	"dolittle.io/fleet-observer/kubernetes"
 	"dolittle.io/fleet-observer/storage"
 	"github.com/rs/zerolog"
-	"k8s.io/client-go/informers"
 	"time"
 )
 
-func StartAllCleanup(period time.Duration, factory informers.SharedInformerFactory, repositories *storage.Repositories, logger zerolog.Logger, ctx context.Context) {
+// StartAllCleanup starts the cleanup jobs for a cluster. If only a single cluster is observed, the instances that were stored
+// before clusters were observed are also cleaned up, since their node UIDs are not prefixed with a cluster.
+func StartAllCleanup(cluster string, single bool, period time.Duration, factories *kubernetes.Factories, repositories *storage.Repositories, logger zerolog.Logger, ctx context.Context) {
 	instancesLogger := logger.With().Str("cleanup", "instances").Logger()
 	instances := &Instances{
+		cluster:      cluster,
+		unscoped:     single,
 		deployments:  repositories.Deployments,
 		environments: repositories.Environments,
 		applications: repositories.Applications,
-		pods:         factory.Core().V1().Pods().Lister(),
+		namespaces:   factories.Cluster.Core().V1().Namespaces().Lister(),
+		restricted:   factories.IsRestricted(),
 		logger:       instancesLogger,
 	}
-	go RunCleaner(instances, period, factory, instancesLogger, ctx)
+	for _, factory := range factories.Namespaced {
+		// the pods are not filtered by the label selector, so that instances of pods that are not observed are not marked as stopped while they are running
+		instances.pods = append(instances.pods, factory.Unfiltered.Core().V1().Pods().Lister())
+		instances.observed = append(instances.observed, factory.Namespace)
+	}
+	go RunCleaner(instances, period, factories, instancesLogger, ctx)
 }
 
 type Cleaner interface {
 	Cleanup(ctx context.Context) error
 }
 
-func RunCleaner(cleaner Cleaner, period time.Duration, factory informers.SharedInformerFactory, logger zerolog.Logger, ctx context.Context) {
+func RunCleaner(cleaner Cleaner, period time.Duration, factories *kubernetes.Factories, logger zerolog.Logger, ctx context.Context) {
 	timer := time.NewTimer(period)
 
 	for {
@@ -40,7 +50,7 @@ func RunCleaner(cleaner Cleaner, period time.Duration, factory informers.SharedI
 		case <-timer.C:
 		}
 
-		factory.WaitForCacheSync(ctx.Done())
+		factories.WaitForCacheSync(ctx.Done())
 
 		logger.Debug().Msg("Running cleanup")
 
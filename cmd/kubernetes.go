@@ -0,0 +1,20 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package cmd
+
+import "github.com/spf13/pflag"
+
+// addKubernetesConnectionFlags adds the flags that configure how to connect to the Kubernetes API server
+func addKubernetesConnectionFlags(flags *pflag.FlagSet) {
+	flags.String("kubernetes.kubeconfig", "", "The kubeconfig file to use, defaults to $KUBECONFIG, ~/.kube/config or the in-cluster config")
+	flags.String("kubernetes.context", "", "The kubeconfig context to use when not observing multiple clusters, defaults to the current context")
+	flags.String("kubernetes.cluster-name", "", "The name to record the cluster as when not observing multiple clusters, defaults to the kubeconfig context, or the UID of the kube-system namespace when using the in-cluster config")
+	flags.String("kubernetes.server", "", "The address of the Kubernetes API server, overrides the kubeconfig or in-cluster config, cannot be used when observing multiple contexts")
+	flags.String("kubernetes.token-file", "", "A file containing the bearer token to authenticate to the Kubernetes API server with, cannot be used when observing multiple contexts")
+	flags.String("kubernetes.ca-file", "", "A file containing the certificate authority of the Kubernetes API server, cannot be used when observing multiple contexts")
+	flags.Float64("kubernetes.qps", 0, "The maximum queries per second to the Kubernetes API server, defaults to the client-go default")
+	flags.Int("kubernetes.burst", 0, "The maximum burst of queries to the Kubernetes API server, defaults to the client-go default")
+}
@@ -6,13 +6,21 @@
 package cmd
 
 import (
+	"context"
 	"dolittle.io/fleet-observer/cleanup"
 	"dolittle.io/fleet-observer/config"
+	"dolittle.io/fleet-observer/entities"
 	"dolittle.io/fleet-observer/kubernetes"
 	"dolittle.io/fleet-observer/observing"
+	"dolittle.io/fleet-observer/sampling"
 	"dolittle.io/fleet-observer/storage"
+	"dolittle.io/fleet-observer/storage/batching"
+	"dolittle.io/fleet-observer/storage/caching"
+	"dolittle.io/fleet-observer/storage/intercepting"
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
-	"k8s.io/client-go/informers"
+	"path/filepath"
+	"strings"
 )
 
 var observe = &cobra.Command{
@@ -24,30 +32,181 @@ var observe = &cobra.Command{
 			return err
 		}
 
-		client, err := kubernetes.NewClientUsing(config)
+		ctx := ContextFromSignals(logger)
+
+		// the storage outlives the observers, so that the pending batches can be written when stopping
+		storageCtx, stopStorage := context.WithCancel(context.Background())
+		defer stopStorage()
+
+		repositories, err := storage.Connect(config, logger, storageCtx)
 		if err != nil {
 			return err
 		}
 
-		factory := informers.NewSharedInformerFactory(client, config.Duration("kubernetes.sync-interval"))
+		// the cache wraps the database directly, so that it only remembers entities that have been written and not just added to a batch
+		if size := config.Int("cache.size"); size > 0 {
+			cache := caching.NewCache(size)
+			repositories = caching.Wrap(repositories, cache)
+			go cache.LogStats(config.Duration("cache.stats-interval"), logger.With().Str("component", "storage").Logger(), ctx)
+		}
 
-		ctx := ContextFromSignals(logger)
+		var batcher *batching.Batcher
+		if size := config.Int("batch.size"); size > 1 {
+			batcher = batching.NewBatcher(size)
+			repositories = batching.Wrap(repositories, batcher)
+			go batcher.Run(config.Duration("batch.interval"), logger.With().Str("component", "storage").Logger(), ctx)
+		}
 
-		repositories, err := storage.Connect(config, logger, ctx)
-		if err != nil {
-			return err
+		if config.Bool("dry-run") {
+			logger.Info().Msg("Running in dry-run mode, changes to entities will be logged instead of written")
+			repositories = intercepting.Wrap(repositories, intercepting.NewDiffLogger(logger), config.Int("skipped.size"))
 		}
 
-		observing.StartAllObservers(factory, repositories, logger, ctx)
-		cleanup.StartAllCleanup(config.Duration("cleanup.interval"), factory, repositories, logger, ctx)
+		parser := observing.NewRuntimeVersionParser(config.Strings("runtime.image-patterns"), newVersionResolverUsing(config))
+
+		if dir := config.String("from-dir"); dir != "" {
+			releases := newReleaseDateResolverUsing(config, repositories)
+			replayErr := replayFromDir(dir, config, repositories, parser, releases, logger, ctx)
+			if err := flushBatches(batcher, logger); err != nil {
+				return err
+			}
+			return replayErr
+		}
+
+		if config.String("record") != "" && config.String("secrets.key") == "" {
+			return kubernetes.ErrSecretsKeyRequired
+		}
+		digester := kubernetes.NewSecretDigesterUsing(config)
+
+		releases := newBackgroundReleaseDateResolverUsing(config, repositories, logger, ctx)
+
+		contexts := kubernetes.GetContextsUsing(config)
+		for _, context := range contexts {
+			info, err := kubernetes.GetClusterUsing(config, context, logger)
+			if err != nil {
+				return err
+			}
+
+			clusterLogger := logger.With().Str("cluster", info.Name).Logger()
+
+			client, err := kubernetes.NewClientUsing(config, context)
+			if err != nil {
+				return err
+			}
+
+			version, err := kubernetes.GetServerVersionUsing(client, info)
+			if err != nil {
+				return err
+			}
 
-		go factory.Start(ctx.Done())
+			cluster := entities.NewCluster(info.Name, info.Server, version)
+			if err := repositories.Clusters.Set(cluster); err != nil {
+				return err
+			}
+			clusterLogger.Info().Str("server", info.Server).Str("assumedIdentity", info.AssumedIdentity).Str("version", version).Msg("Connected to cluster")
 
+			if len(contexts) == 1 {
+				if err := warnAboutUnscopedNodes(repositories, clusterLogger); err != nil {
+					return err
+				}
+			}
+
+			factories, err := kubernetes.NewFactoriesUsing(config, client, kubernetes.NewAccessReviewer(client, ctx))
+			if err != nil {
+				return err
+			}
+
+			observing.StartAllObservers(info.Name, factories, repositories, parser, releases, digester, clusterLogger, ctx)
+			cleanup.StartAllCleanup(info.Name, len(contexts) == 1, config.Duration("cleanup.interval"), factories, repositories, clusterLogger, ctx)
+
+			if config.Bool("metrics.enabled") {
+				metricsClient, err := kubernetes.NewMetricsClientUsing(config, context)
+				if err != nil {
+					return err
+				}
+
+				if err := sampling.StartUsageSampling(config.Duration("metrics.interval"), config.Duration("metrics.window"), metricsClient, factories, repositories, clusterLogger, ctx); err != nil {
+					return err
+				}
+			}
+
+			if dir := config.String("record"); dir != "" {
+				recorder, err := kubernetes.NewRecorder(filepath.Join(dir, info.Name), config.Int64("recording.segment-size"), config.Int("recording.max-segments"), digester, clusterLogger)
+				if err != nil {
+					return err
+				}
+				recorder.RecordAll(factories)
+				go func() {
+					<-ctx.Done()
+					recorder.Close()
+				}()
+			}
+
+			go factories.Start(ctx.Done())
+		}
+
+		<-ctx.Done()
+		if err := flushBatches(batcher, logger); err != nil {
+			logger.Error().Err(err).Msg("Failed to write pending batches")
+		}
 		return WaitForStop(logger, ctx)
 	},
 }
 
+// warnAboutUnscopedNodes warns if there are Nodes that were stored before clusters were observed, since they are identified without a cluster.
+// They are not migrated, so the Nodes that are observed now are stored separately from them, and only the old links point to them.
+func warnAboutUnscopedNodes(repositories *storage.Repositories, logger zerolog.Logger) error {
+	nodes, err := repositories.Nodes.List()
+	if err != nil {
+		return err
+	}
+
+	unscoped := 0
+	for _, node := range nodes {
+		if !strings.Contains(string(node.UID), "/") {
+			unscoped++
+		}
+	}
+	if unscoped > 0 {
+		logger.Warn().Int("nodes", unscoped).Msg("Found nodes that were stored before clusters were observed, they are not migrated and are stored separately from the nodes that are observed in the cluster")
+	}
+	return nil
+}
+
+// flushBatches writes the pending batches if batching is enabled
+func flushBatches(batcher *batching.Batcher, logger zerolog.Logger) error {
+	if batcher == nil {
+		return nil
+	}
+
+	logger.Debug().Msg("Writing pending batches")
+	return batcher.Flush()
+}
+
 func init() {
+	observe.Flags().Bool("dry-run", false, "Read from the database, but log the changes to entities instead of writing them")
+	observe.Flags().Int("skipped.size", 100000, "The number of entities of each kind that were not written in dry-run mode to remember, so that they are returned when read back")
+	observe.Flags().StringSlice("kubernetes.contexts", nil, "The kubeconfig contexts of the clusters to observe, defaults to the current context or the in-cluster config")
+	addKubernetesConnectionFlags(observe.Flags())
+	observe.Flags().StringSlice("kubernetes.namespaces", nil, "The namespaces to observe namespaced resources in, defaults to all namespaces")
+	observe.Flags().String("kubernetes.label-selector", "", "A label selector to filter the observed Pods, ReplicaSets, Jobs, HorizontalPodAutoscalers, Services and Ingresses with")
 	observe.Flags().String("kubernetes.sync-interval", "1m", "The Kubernetes informer sync interval")
+	observe.Flags().String("from-dir", "", "Replay the recorded Kubernetes objects in a directory instead of observing a cluster, and exit when they have been handled")
+	observe.Flags().String("record", "", "Record the Kubernetes objects seen by the observers to a directory, with a subdirectory for each cluster that can be replayed using --from-dir")
+	observe.Flags().Int64("recording.segment-size", 16*1024*1024, "The size in bytes of the changes recorded in a segment before a new segment is started")
+	observe.Flags().Int("recording.max-segments", 100, "The number of recording segments to keep for each cluster")
+	observe.Flags().String("secrets.key", "", "The key to compute the digests of Secret values in configuration hashes and recordings with, required when recording. Can also be set using the SECRETS_KEY environment variable")
+	observe.Flags().String("replay.cluster", kubernetes.DefaultClusterName, "The name of the cluster the replayed objects were recorded from")
+	observe.Flags().String("replay.idle", "2s", "The time the observers must be idle before a replay is considered finished")
+	observe.Flags().Int("replay.max-retries", 10, "The number of times to retry handling a replayed object before giving up, the replay fails if any objects could not be handled")
+	observe.Flags().Int("batch.size", 100, "The number of entities of the same kind to write to the database in a single batch, 1 disables batching")
+	observe.Flags().String("batch.interval", "100ms", "The interval to write batches that are not full")
+	observe.Flags().Int("cache.size", 10000, "The number of written entities to remember to skip writing unchanged entities, 0 disables the cache")
+	observe.Flags().String("cache.stats-interval", "5m", "The interval to log the hits and misses of the storage cache, they are not exposed in any other way")
 	observe.Flags().String("cleanup.interval", "1m", "The interval to run cleanup jobs")
+	observe.Flags().StringSlice("runtime.image-patterns", observing.DefaultRuntimeImagePatterns, "Patterns of runtime container image repositories to parse runtime versions from, with or without the registry host")
+	addRegistryFlags(observe.Flags())
+	observe.Flags().Bool("metrics.enabled", false, "Sample resource usage of deployment instances from the metrics.k8s.io API")
+	observe.Flags().String("metrics.interval", "30s", "The interval to sample resource usage from the metrics.k8s.io API")
+	observe.Flags().String("metrics.window", "1h", "The window to aggregate resource usage samples over")
 }
@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package cmd
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/config"
+	"dolittle.io/fleet-observer/kubernetes"
+	"dolittle.io/fleet-observer/observing"
+	"dolittle.io/fleet-observer/registry"
+	"dolittle.io/fleet-observer/storage"
+	"errors"
+	"fmt"
+	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+	"strings"
+)
+
+var ErrMissingPermissions = errors.New("the observer is missing required permissions")
+
+var rbac = &cobra.Command{
+	Use:   "rbac",
+	Short: "Generates the RBAC manifests required to run the observer",
+	Long: `Generates the ServiceAccount, ClusterRole, Roles and bindings required to run the observer with the current configuration.
+The required permissions are derived from the informers that the observer starts.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		config, logger, err := config.SetupFor(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx := ContextFromSignals(logger)
+
+		permissions, err := getRequiredPermissions(config, ctx)
+		if err != nil {
+			return err
+		}
+
+		manifests := kubernetes.NewRBACManifests(config.String("rbac.name"), config.String("rbac.namespace"), permissions)
+		for _, manifest := range manifests {
+			data, err := yaml.Marshal(manifest)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "---\n%s", data)
+		}
+
+		return nil
+	},
+}
+
+var rbacCheck = &cobra.Command{
+	Use:   "check",
+	Short: "Checks that the observer has the required permissions in the clusters",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		config, logger, err := config.SetupFor(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx := ContextFromSignals(logger)
+
+		permissions, err := getRequiredPermissions(config, ctx)
+		if err != nil {
+			return err
+		}
+
+		missing := 0
+		for _, context := range kubernetes.GetContextsUsing(config) {
+			info, err := kubernetes.GetClusterUsing(config, context, logger)
+			if err != nil {
+				return err
+			}
+
+			client, err := kubernetes.NewClientUsing(config, context)
+			if err != nil {
+				return err
+			}
+
+			if _, err := kubernetes.GetServerVersionUsing(client, info); err != nil {
+				return err
+			}
+			logger.Info().Str("cluster", info.Name).Str("server", info.Server).Str("assumedIdentity", info.AssumedIdentity).Msg("Checking permissions")
+
+			access := kubernetes.NewAccessReviewer(client, ctx)
+			for _, permission := range permissions {
+				for _, verb := range permission.Verbs {
+					allowed, err := access.Can(verb, permission.Group, permission.Resource, permission.Namespace)
+					if err != nil {
+						return err
+					}
+
+					if allowed {
+						logger.Debug().Str("cluster", info.Name).Str("verb", verb).Str("resource", formatResource(permission)).Str("namespace", permission.Namespace).Msg("Allowed")
+						continue
+					}
+
+					missing++
+					logger.Error().Str("cluster", info.Name).Str("verb", verb).Str("resource", formatResource(permission)).Str("namespace", permission.Namespace).Msg("Missing permission")
+				}
+			}
+		}
+
+		if missing > 0 {
+			return fmt.Errorf("%w: %d missing", ErrMissingPermissions, missing)
+		}
+
+		logger.Info().Msg("The observer has all the required permissions")
+		return nil
+	},
+}
+
+// getRequiredPermissions starts the observers with a fake client to find the informers they use, and returns the permissions they need
+func getRequiredPermissions(config *koanf.Koanf, ctx context.Context) ([]kubernetes.Permission, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	factories, err := kubernetes.NewFactoriesUsing(config, fake.NewSimpleClientset(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases registry.ReleaseDateResolver = registry.NoReleaseDateResolver{}
+	parser := observing.NewRuntimeVersionParser(nil, nil)
+	observing.StartAllObservers("", factories, &storage.Repositories{}, parser, releases, kubernetes.SecretDigester{}, zerolog.Nop(), ctx)
+
+	permissions, err := factories.GetRequiredPermissions(ctx.Done())
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Bool("metrics.enabled") {
+		for _, factory := range factories.Namespaced {
+			permissions = append(permissions, kubernetes.Permission{
+				Group:     "metrics.k8s.io",
+				Resource:  "pods",
+				Namespace: factory.Namespace,
+				Verbs:     []string{"list"},
+			})
+		}
+	}
+
+	return permissions, nil
+}
+
+func formatResource(permission kubernetes.Permission) string {
+	if permission.Group == "" {
+		return permission.Resource
+	}
+	return strings.Join([]string{permission.Resource, permission.Group}, ".")
+}
+
+func init() {
+	rbac.PersistentFlags().StringSlice("kubernetes.contexts", nil, "The kubeconfig contexts of the clusters to observe, defaults to the current context or the in-cluster config")
+	rbac.PersistentFlags().StringSlice("kubernetes.namespaces", nil, "The namespaces to observe namespaced resources in, defaults to all namespaces")
+	rbac.PersistentFlags().String("kubernetes.label-selector", "", "A label selector to filter the observed Pods, ReplicaSets, Jobs, HorizontalPodAutoscalers, Services and Ingresses with")
+	rbac.PersistentFlags().Bool("metrics.enabled", false, "Sample resource usage of deployment instances from the metrics.k8s.io API")
+	rbac.Flags().String("rbac.name", "fleet-observer", "The name of the ServiceAccount, roles and bindings to generate")
+	rbac.Flags().String("rbac.namespace", "default", "The namespace of the ServiceAccount to generate")
+
+	addKubernetesConnectionFlags(rbacCheck.Flags())
+
+	rbac.AddCommand(rbacCheck)
+}
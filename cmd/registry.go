@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package cmd
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/registry"
+	"dolittle.io/fleet-observer/storage"
+	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+	"github.com/spf13/pflag"
+)
+
+// addRegistryFlags adds the flags that configure how release dates are resolved from container registries
+func addRegistryFlags(flags *pflag.FlagSet) {
+	flags.Bool("registry.resolve-release-dates", false, "Resolve artifact and runtime release dates from the image creation time in the container registry")
+	flags.Bool("registry.resolve-runtime-versions", false, "Resolve the versions of runtime images referenced by digest without a version tag from the org.opencontainers.image.version label in the container registry")
+	flags.StringSlice("registry.insecure", nil, "Container registry hosts to connect to using plain HTTP")
+	flags.String("registry.platform", "linux/amd64", "The image platform to resolve release dates for from multi-platform images")
+	flags.String("registry.timeout", "10s", "The timeout for requests to container registries")
+	flags.String("registry.retry-interval", "1h", "The interval to wait before retrying to resolve release dates that could not be resolved")
+	flags.String("registry.cache-expiry", "24h", "The interval after which release dates of images referenced by tag are resolved again, since the tag can be moved to another image")
+}
+
+// newVersionResolverUsing creates the resolver of runtime versions from image labels, or nil if the versions should not be resolved
+func newVersionResolverUsing(config *koanf.Koanf) registry.VersionResolver {
+	if !config.Bool("registry.resolve-runtime-versions") {
+		return nil
+	}
+	return registry.NewDistributionResolverUsing(config)
+}
+
+// newReleaseDateResolverUsing creates the release date resolver configured by the registry flags, that waits for release dates to be resolved
+func newReleaseDateResolverUsing(config *koanf.Koanf, repositories *storage.Repositories) registry.ReleaseDateResolver {
+	if !config.Bool("registry.resolve-release-dates") {
+		return registry.NoReleaseDateResolver{}
+	}
+	return newCachedResolverUsing(config, repositories)
+}
+
+// newBackgroundReleaseDateResolverUsing creates the release date resolver configured by the registry flags, that resolves release dates in the background
+func newBackgroundReleaseDateResolverUsing(config *koanf.Koanf, repositories *storage.Repositories, logger zerolog.Logger, ctx context.Context) registry.ReleaseDateResolver {
+	if !config.Bool("registry.resolve-release-dates") {
+		return registry.NoReleaseDateResolver{}
+	}
+	return registry.NewBackgroundResolver(newCachedResolverUsing(config, repositories), logger, ctx)
+}
+
+func newCachedResolverUsing(config *koanf.Koanf, repositories *storage.Repositories) *registry.CachedResolver {
+	return registry.NewCachedResolver(
+		registry.NewDistributionResolverUsing(config),
+		repositories.Images,
+		config.Duration("registry.retry-interval"),
+		config.Duration("registry.cache-expiry"),
+	)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package cmd
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/kubernetes"
+	"dolittle.io/fleet-observer/observing"
+	"dolittle.io/fleet-observer/registry"
+	"dolittle.io/fleet-observer/storage"
+	"errors"
+	"fmt"
+	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// ErrNotAllHandled is returned when the observers could not handle all the replayed or reprocessed objects
+var ErrNotAllHandled = errors.New("not all objects could be handled")
+
+// replayFromDir runs the observers on the recorded Kubernetes objects in a directory instead of a live cluster,
+// and returns when all the observers have handled the objects
+func replayFromDir(dir string, config *koanf.Koanf, repositories *storage.Repositories, parser observing.RuntimeVersionParser, releases registry.ReleaseDateResolver, logger zerolog.Logger, ctx context.Context) error {
+	objects, err := kubernetes.LoadObjectsFromDir(dir)
+	if err != nil {
+		return err
+	}
+
+	cluster := config.String("replay.cluster")
+	clusterLogger := logger.With().Str("cluster", cluster).Logger()
+	clusterLogger.Info().Str("directory", dir).Int("objects", len(objects)).Msg("Replaying recorded objects")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	factories, err := kubernetes.NewFactoriesUsing(config, fake.NewSimpleClientset(objects...), nil)
+	if err != nil {
+		return err
+	}
+
+	observers := observing.StartAllObservers(cluster, factories, repositories, parser, releases, kubernetes.NewSecretDigesterUsing(config), clusterLogger, ctx)
+	for _, observer := range observers {
+		observer.StopRetryingAfter(config.Int("replay.max-retries"))
+	}
+
+	factories.Start(ctx.Done())
+	factories.WaitForCacheSync(ctx.Done())
+	kubernetes.WaitUntilIdle(observers, config.Duration("replay.idle"), ctx.Done())
+
+	if err := ctx.Err(); err != nil {
+		clusterLogger.Info().Msg("Replay was stopped before all objects were handled")
+		return nil
+	}
+
+	if err := checkAllHandled(observers, clusterLogger); err != nil {
+		return err
+	}
+
+	clusterLogger.Info().Msg("Replay finished")
+	return nil
+}
+
+// checkAllHandled returns an ErrNotAllHandled error if any of the observers gave up or are still retrying handling some objects
+func checkAllHandled(observers []*kubernetes.Observer, logger zerolog.Logger) error {
+	unhandled := 0
+	for _, observer := range observers {
+		if failed := observer.Failed() + observer.Failing(); failed > 0 {
+			logger.Warn().Str("observer", observer.Name()).Int("failed", failed).Msg("Some objects could not be handled")
+			unhandled += failed
+		}
+	}
+
+	if unhandled > 0 {
+		return fmt.Errorf("%w: %d failed", ErrNotAllHandled, unhandled)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package cmd
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/config"
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/kubernetes"
+	"dolittle.io/fleet-observer/observing"
+	"dolittle.io/fleet-observer/registry"
+	"dolittle.io/fleet-observer/storage"
+	"dolittle.io/fleet-observer/storage/intercepting"
+	"errors"
+	"fmt"
+	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"text/tabwriter"
+)
+
+var reprocess = &cobra.Command{
+	Use:   "reprocess",
+	Short: "Recomputes the stored entities from the current state of the clusters",
+	Long: `Lists all the resources in the clusters once, runs them through the observers, and exits when they have been handled.
+Prints the number of entities of each type that were created, updated or unchanged compared to the stored entities.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		config, logger, err := config.SetupFor(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx := ContextFromSignals(logger)
+
+		repositories, err := storage.Connect(config, logger, ctx)
+		if err != nil {
+			return err
+		}
+
+		dryRun := config.Bool("dry-run")
+		if dryRun {
+			logger.Info().Msg("Running in dry-run mode, no entities will be written")
+		}
+
+		counter := intercepting.NewCounter(!dryRun)
+		repositories = intercepting.Wrap(repositories, counter, config.Int("skipped.size"))
+
+		releases := newReleaseDateResolverUsing(config, repositories)
+		parser := observing.NewRuntimeVersionParser(config.Strings("runtime.image-patterns"), newVersionResolverUsing(config))
+
+		var notAllHandled error
+		for _, context := range kubernetes.GetContextsUsing(config) {
+			err := reprocessCluster(context, config, repositories, parser, releases, logger, ctx)
+			if errors.Is(err, ErrNotAllHandled) {
+				notAllHandled = err
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			logger.Info().Msg("Reprocessing was stopped before all resources were handled")
+			return nil
+		}
+
+		writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "TYPE\tCREATED\tUPDATED\tUNCHANGED")
+		for _, counts := range counter.Counts() {
+			fmt.Fprintf(writer, "%v\t%d\t%d\t%d\n", counts.Type, counts.Created, counts.Updated, counts.Unchanged)
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		return notAllHandled
+	},
+}
+
+// reprocessCluster runs the observers on all the resources in a cluster, and returns when all the observers have handled them
+func reprocessCluster(kubeContext string, config *koanf.Koanf, repositories *storage.Repositories, parser observing.RuntimeVersionParser, releases registry.ReleaseDateResolver, logger zerolog.Logger, ctx context.Context) error {
+	info, err := kubernetes.GetClusterUsing(config, kubeContext, logger)
+	if err != nil {
+		return err
+	}
+
+	clusterLogger := logger.With().Str("cluster", info.Name).Logger()
+
+	client, err := kubernetes.NewClientUsing(config, kubeContext)
+	if err != nil {
+		return err
+	}
+
+	version, err := kubernetes.GetServerVersionUsing(client, info)
+	if err != nil {
+		return err
+	}
+
+	if err := repositories.Clusters.Set(entities.NewCluster(info.Name, info.Server, version)); err != nil {
+		return err
+	}
+	clusterLogger.Info().Str("server", info.Server).Str("assumedIdentity", info.AssumedIdentity).Str("version", version).Msg("Reprocessing cluster")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	factories, err := kubernetes.NewFactoriesUsing(config, client, kubernetes.NewAccessReviewer(client, ctx))
+	if err != nil {
+		return err
+	}
+
+	observers := observing.StartAllObservers(info.Name, factories, repositories, parser, releases, kubernetes.NewSecretDigesterUsing(config), clusterLogger, ctx)
+	for _, observer := range observers {
+		observer.StopRetryingAfter(config.Int("reprocess.max-retries"))
+	}
+
+	factories.Start(ctx.Done())
+	factories.WaitForCacheSync(ctx.Done())
+	kubernetes.WaitUntilIdle(observers, config.Duration("reprocess.idle"), ctx.Done())
+
+	if err := ctx.Err(); err != nil {
+		return nil
+	}
+	return checkAllHandled(observers, clusterLogger)
+}
+
+func init() {
+	reprocess.Flags().Bool("dry-run", false, "Only compare the recomputed entities to the stored entities, without writing them")
+	reprocess.Flags().Int("skipped.size", 100000, "The number of entities of each kind that were not written to remember, so that they are returned when read back")
+	reprocess.Flags().StringSlice("kubernetes.contexts", nil, "The kubeconfig contexts of the clusters to reprocess, defaults to the current context or the in-cluster config")
+	addKubernetesConnectionFlags(reprocess.Flags())
+	reprocess.Flags().StringSlice("kubernetes.namespaces", nil, "The namespaces to reprocess namespaced resources in, defaults to all namespaces")
+	reprocess.Flags().String("kubernetes.label-selector", "", "A label selector to filter the observed Pods, ReplicaSets, Jobs, HorizontalPodAutoscalers, Services and Ingresses with")
+	reprocess.Flags().String("reprocess.idle", "2s", "The time the observers must be idle before all resources are considered handled")
+	reprocess.Flags().Int("reprocess.max-retries", 10, "The number of times to retry handling a resource before giving up, reprocessing fails if any resources could not be handled")
+	reprocess.Flags().String("secrets.key", "", "The key to compute the digests of Secret values in configuration hashes with, must be the same as the observer uses. Can also be set using the SECRETS_KEY environment variable")
+	reprocess.Flags().StringSlice("runtime.image-patterns", observing.DefaultRuntimeImagePatterns, "Patterns of runtime container image repositories to parse runtime versions from, with or without the registry host")
+	addRegistryFlags(reprocess.Flags())
+}
@@ -32,4 +32,6 @@ func init() {
 	root.AddCommand(observe)
 	root.AddCommand(drop)
 	root.AddCommand(export)
+	root.AddCommand(rbac)
+	root.AddCommand(reprocess)
 }
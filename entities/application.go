@@ -5,7 +5,10 @@
 
 package entities
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type ApplicationUID string
 
@@ -16,8 +19,10 @@ type Application struct {
 	Type string         `bson:"_type" json:"type"`
 
 	Properties struct {
-		ID   string `bson:"id" json:"id"`
-		Name string `bson:"name" json:"name"`
+		ID      string     `bson:"id" json:"id"`
+		Name    string     `bson:"name" json:"name"`
+		Created time.Time  `bson:"created" json:"created"`
+		Deleted *time.Time `bson:"deleted" json:"deleted,omitempty"`
 	} `bson:"properties" json:"properties"`
 
 	Links struct {
@@ -29,12 +34,14 @@ func NewApplicationUID(customerID, applicationID string) ApplicationUID {
 	return ApplicationUID(fmt.Sprintf("%v/%v", NewCustomerUID(customerID), applicationID))
 }
 
-func NewApplication(customerID, id, name string) Application {
+func NewApplication(customerID, id, name string, created time.Time, deleted *time.Time) Application {
 	application := Application{}
 	application.UID = NewApplicationUID(customerID, id)
 	application.Type = ApplicationType
 	application.Properties.ID = id
 	application.Properties.Name = name
+	application.Properties.Created = created
+	application.Properties.Deleted = deleted
 	application.Links.OwnedByCustomerUID = NewCustomerUID(customerID)
 	return application
 }
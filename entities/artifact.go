@@ -49,8 +49,9 @@ type ArtifactVersion struct {
 	Type string             `bson:"_type" json:"type"`
 
 	Properties struct {
-		Name     string    `bson:"name" json:"name"`
-		Released time.Time `bson:"released" json:"-"`
+		Name     string     `bson:"name" json:"name"`
+		Released *time.Time `bson:"released" json:"released,omitempty"`
+		Digests  []string   `bson:"digests" json:"digests,omitempty"`
 	} `bson:"properties" json:"properties"`
 
 	Links struct {
@@ -62,7 +63,7 @@ func NewArtifactVersionUID(customerID, artifactID, artifactVersionID string) Art
 	return ArtifactVersionUID(fmt.Sprintf("%v/%v", NewArtifactUID(customerID, artifactID), artifactVersionID))
 }
 
-func NewArtifactVersion(customerID, artifactID, name string, released time.Time) ArtifactVersion {
+func NewArtifactVersion(customerID, artifactID, name string, released *time.Time) ArtifactVersion {
 	version := ArtifactVersion{}
 	version.UID = NewArtifactVersionUID(customerID, artifactID, name)
 	version.Type = ArtifactVersionType
@@ -71,3 +72,20 @@ func NewArtifactVersion(customerID, artifactID, name string, released time.Time)
 	version.Links.VersionOfArtifactUID = NewArtifactUID(customerID, artifactID)
 	return version
 }
+
+// MergeArtifactVersionDigests returns the next version with the digests of the previous version that it does not already have added before its own,
+// which is how the digests of a stored version are updated
+func MergeArtifactVersionDigests(previous, next ArtifactVersion) ArtifactVersion {
+	var digests []string
+	known := map[string]struct{}{}
+	for _, list := range [][]string{previous.Properties.Digests, next.Properties.Digests} {
+		for _, digest := range list {
+			if _, ok := known[digest]; !ok {
+				known[digest] = struct{}{}
+				digests = append(digests, digest)
+			}
+		}
+	}
+	next.Properties.Digests = digests
+	return next
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package entities
+
+import "fmt"
+
+type ClusterUID string
+
+var ClusterType = "Cluster"
+
+type Cluster struct {
+	UID  ClusterUID `bson:"_id" json:"uid"`
+	Type string     `bson:"_type" json:"type"`
+
+	Properties struct {
+		Name    string `bson:"name" json:"name"`
+		Server  string `bson:"server" json:"server"`
+		Version string `bson:"version" json:"version,omitempty"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+	} `bson:"links" json:"-"`
+}
+
+func NewClusterUID(name string) ClusterUID {
+	return ClusterUID(fmt.Sprintf("%v", name))
+}
+
+func NewCluster(name, server, version string) Cluster {
+	cluster := Cluster{}
+	cluster.UID = NewClusterUID(name)
+	cluster.Type = ClusterType
+	cluster.Properties.Name = name
+	cluster.Properties.Server = server
+	cluster.Properties.Version = version
+	return cluster
+}
@@ -5,18 +5,34 @@
 
 package entities
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type ArtifactConfigurationUID string
 
 var ArtifactConfigurationType = "ArtifactConfiguration"
 
+// ConfigurationSecrets describes how the Secret was included in the content hash of an ArtifactConfiguration
+type ConfigurationSecrets string
+
+const (
+	// ConfigurationSecretsIncluded means the values of the Secret were hashed
+	ConfigurationSecretsIncluded ConfigurationSecrets = "included"
+	// ConfigurationSecretsRedacted means the unkeyed hashes of the values of a Secret recorded by an earlier version were hashed, so the content hash differs from the live one
+	ConfigurationSecretsRedacted ConfigurationSecrets = "redacted"
+	// ConfigurationSecretsExcluded means the observer was not allowed to read the Secret, so it was left out of the content hash
+	ConfigurationSecretsExcluded ConfigurationSecrets = "excluded"
+)
+
 type ArtifactConfiguration struct {
 	UID  ArtifactConfigurationUID `bson:"_id" json:"uid"`
 	Type string                   `bson:"_type" json:"type"`
 
 	Properties struct {
-		ContentHash string `bson:"content_hash" json:"hash"`
+		ContentHash string               `bson:"content_hash" json:"hash"`
+		Secrets     ConfigurationSecrets `bson:"secrets" json:"secrets"`
 	} `bson:"properties" json:"properties"`
 
 	Links struct {
@@ -27,11 +43,12 @@ func NewArtifactConfigurationUID(customerID, applicationID, environment, artifac
 	return ArtifactConfigurationUID(configurationUID(customerID, applicationID, environment, artifactID, contentHash))
 }
 
-func NewArtifactConfiguration(customerID, applicationID, environment, artifactID, contentHash string) ArtifactConfiguration {
+func NewArtifactConfiguration(customerID, applicationID, environment, artifactID, contentHash string, secrets ConfigurationSecrets) ArtifactConfiguration {
 	configuration := ArtifactConfiguration{}
 	configuration.UID = NewArtifactConfigurationUID(customerID, applicationID, environment, artifactID, contentHash)
 	configuration.Type = ArtifactConfigurationType
 	configuration.Properties.ContentHash = contentHash
+	configuration.Properties.Secrets = secrets
 	return configuration
 }
 
@@ -66,3 +83,44 @@ func NewRuntimeConfiguration(customerID, applicationID, environment, artifactID,
 func configurationUID(customerID, applicationID, environment, artifactID, contentHash string) string {
 	return fmt.Sprintf("%v/%v/%v", NewEnvironmentUID(customerID, applicationID, environment), artifactID, contentHash)
 }
+
+type NodeConfigurationUID string
+
+var NodeConfigurationType = "NodeConfiguration"
+
+type NodeConfiguration struct {
+	UID  NodeConfigurationUID `bson:"_id" json:"uid"`
+	Type string               `bson:"_type" json:"type"`
+
+	Properties struct {
+		ContentHash string `bson:"content_hash" json:"hash"`
+		Image       string `bson:"image" json:"image"`
+		Type        string `bson:"type" json:"type"`
+		NodeInfo    `bson:",inline"`
+		Capacity    NodeCapacity `bson:"capacity" json:"capacity"`
+		// ObservedFrom and ObservedTo are the times when the node had the configuration, ObservedTo is not set while the node still has it
+		ObservedFrom time.Time  `bson:"observed_from" json:"observedFrom"`
+		ObservedTo   *time.Time `bson:"observed_to" json:"observedTo,omitempty"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+		ConfigurationOfNodeUID NodeUID `bson:"configuration_of_node_uid" json:"configurationOf"`
+	} `bson:"links" json:"links"`
+}
+
+func NewNodeConfigurationUID(node NodeUID, contentHash string) NodeConfigurationUID {
+	return NodeConfigurationUID(fmt.Sprintf("%v/%v", node, contentHash))
+}
+
+func NewNodeConfiguration(node Node, contentHash string) NodeConfiguration {
+	configuration := NodeConfiguration{}
+	configuration.UID = NewNodeConfigurationUID(node.UID, contentHash)
+	configuration.Type = NodeConfigurationType
+	configuration.Properties.ContentHash = contentHash
+	configuration.Properties.Image = node.Properties.Image
+	configuration.Properties.Type = node.Properties.Type
+	configuration.Properties.NodeInfo = node.Properties.NodeInfo
+	configuration.Properties.Capacity = node.Properties.Capacity
+	configuration.Links.ConfigurationOfNodeUID = node.UID
+	return configuration
+}
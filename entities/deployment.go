@@ -19,9 +19,10 @@ type Deployment struct {
 	Type string        `bson:"_type" json:"type"`
 
 	Properties struct {
-		ID      string    `bson:"id" json:"id"`
-		Name    string    `bson:"name" json:"name"`
-		Created time.Time `bson:"created" json:"created"`
+		ID        string          `bson:"id" json:"id"`
+		Name      string          `bson:"name" json:"name"`
+		Created   time.Time       `bson:"created" json:"created"`
+		Resources ResourceProfile `bson:"resources" json:"resources"`
 	} `bson:"properties" json:"properties"`
 
 	Links struct {
@@ -35,13 +36,14 @@ func NewDeploymentUID(customerID, applicationID, environment, deploymentID strin
 	return DeploymentUID(fmt.Sprintf("%v/%v", NewEnvironmentUID(customerID, applicationID, environment), deploymentID))
 }
 
-func NewDeployment(customerID, applicationID, environment, id, name string, created time.Time, artifact ArtifactVersion, runtime RuntimeVersion) Deployment {
+func NewDeployment(customerID, applicationID, environment, id, name string, created time.Time, resources ResourceProfile, artifact ArtifactVersion, runtime RuntimeVersion) Deployment {
 	deployment := Deployment{}
 	deployment.UID = NewDeploymentUID(customerID, applicationID, environment, id)
 	deployment.Type = DeploymentType
 	deployment.Properties.ID = id
 	deployment.Properties.Name = name
 	deployment.Properties.Created = created
+	deployment.Properties.Resources = resources
 	deployment.Links.DeployedInEnvironmentUID = NewEnvironmentUID(customerID, applicationID, environment)
 	deployment.Links.UsesArtifactVersionUID = artifact.UID
 	deployment.Links.UsesRuntimeVersionUID = runtime.UID
@@ -57,9 +59,14 @@ type DeploymentInstance struct {
 	Type string                `bson:"_type" json:"type"`
 
 	Properties struct {
-		ID      string     `bson:"id" json:"id"`
-		Started time.Time  `bson:"started" json:"started"`
-		Stopped *time.Time `bson:"stopped" json:"stopped,omitempty"`
+		ID             string     `bson:"id" json:"id"`
+		Started        time.Time  `bson:"started" json:"started"`
+		Stopped        *time.Time `bson:"stopped" json:"stopped,omitempty"`
+		QOSClass       string     `bson:"qos_class" json:"qosClass,omitempty"`
+		ArtifactDigest string     `bson:"artifact_digest" json:"artifactDigest,omitempty"`
+		RuntimeDigest  string     `bson:"runtime_digest" json:"runtimeDigest,omitempty"`
+		Status         string     `bson:"status" json:"status,omitempty"`
+		Duration       *int       `bson:"duration" json:"duration,omitempty"`
 	} `bson:"properties" json:"properties"`
 
 	Links struct {
@@ -67,23 +74,35 @@ type DeploymentInstance struct {
 		UsesArtifactConfigurationUID ArtifactConfigurationUID `bson:"uses_artifact_configuration_uid" json:"usesArtifactConfiguration"`
 		UsesRuntimeConfigurationUID  RuntimeConfigurationUID  `bson:"uses_runtime_configuration_uid" json:"usesRuntimeConfiguration"`
 		ScheduledOnNodeUID           NodeUID                  `bson:"scheduled_on_node_uid" json:"scheduledOn"`
+		RanOnNodeConfigurationUID    NodeConfigurationUID     `bson:"ran_on_node_configuration_uid" json:"ranOn,omitempty"`
 	} `bson:"links" json:"links"`
 }
 
+// The status of a DeploymentInstance is only set for instances of Jobs
+var (
+	RunningDeploymentInstanceStatus   = "Running"
+	SucceededDeploymentInstanceStatus = "Succeeded"
+	FailedDeploymentInstanceStatus    = "Failed"
+)
+
 func NewDeploymentInstanceUID(customerID, applicationID, environment, deploymentID, deploymentInstanceID string) DeploymentInstanceUID {
 	return DeploymentInstanceUID(fmt.Sprintf("%v/%v", NewDeploymentUID(customerID, applicationID, environment, deploymentID), deploymentInstanceID))
 }
 
-func NewDeploymentInstance(customerID, applicationID, environment, deploymentID, id string, started time.Time, stopped *time.Time, artifact ArtifactConfiguration, runtime RuntimeConfiguration, nodeName string) DeploymentInstance {
+func NewDeploymentInstance(customerID, applicationID, environment, deploymentID, id string, started time.Time, stopped *time.Time, qosClass, artifactDigest, runtimeDigest string, artifact ArtifactConfiguration, runtime RuntimeConfiguration, node NodeUID, nodeConfiguration NodeConfigurationUID) DeploymentInstance {
 	instance := DeploymentInstance{}
 	instance.UID = NewDeploymentInstanceUID(customerID, applicationID, environment, deploymentID, id)
 	instance.Type = DeploymentInstanceType
 	instance.Properties.ID = id
 	instance.Properties.Started = started
 	instance.Properties.Stopped = stopped
+	instance.Properties.QOSClass = qosClass
+	instance.Properties.ArtifactDigest = artifactDigest
+	instance.Properties.RuntimeDigest = runtimeDigest
 	instance.Links.InstanceOfDeploymentUID = NewDeploymentUID(customerID, applicationID, environment, deploymentID)
 	instance.Links.UsesArtifactConfigurationUID = artifact.UID
 	instance.Links.UsesRuntimeConfigurationUID = runtime.UID
-	instance.Links.ScheduledOnNodeUID = NewNodeUID(nodeName)
+	instance.Links.ScheduledOnNodeUID = node
+	instance.Links.RanOnNodeConfigurationUID = nodeConfiguration
 	return instance
 }
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type EndpointUID string
+
+var EndpointType = "Endpoint"
+
+var (
+	IngressEndpointKind = "Ingress"
+	ServiceEndpointKind = "Service"
+)
+
+type Endpoint struct {
+	UID  EndpointUID `bson:"_id" json:"uid"`
+	Type string      `bson:"_type" json:"type"`
+
+	Properties struct {
+		Kind    string     `bson:"kind" json:"kind"`
+		Name    string     `bson:"name" json:"name"`
+		Host    string     `bson:"host" json:"host"`
+		Path    string     `bson:"path" json:"path"`
+		Port    int        `bson:"port" json:"port"`
+		TLS     bool       `bson:"tls" json:"tls"`
+		Public  *bool      `bson:"public" json:"public,omitempty"`
+		Deleted *time.Time `bson:"deleted" json:"deleted,omitempty"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+		ExposedInEnvironmentUID EnvironmentUID `bson:"exposed_in_environment_uid" json:"exposedIn"`
+		RoutesToArtifactUID     ArtifactUID    `bson:"routes_to_artifact_uid" json:"routesTo,omitempty"`
+	} `bson:"links" json:"links"`
+}
+
+func NewEndpointUID(customerID, applicationID, environment, kind, name, host, path string, port int) EndpointUID {
+	return EndpointUID(fmt.Sprintf("%v/%v/%v/%v:%v%v", NewEnvironmentUID(customerID, applicationID, environment), kind, name, host, port, path))
+}
+
+func NewEndpoint(customerID, applicationID, environment, kind, name, host, path string, port int, tls bool, public *bool, artifactID string) Endpoint {
+	endpoint := Endpoint{}
+	endpoint.UID = NewEndpointUID(customerID, applicationID, environment, kind, name, host, path, port)
+	endpoint.Type = EndpointType
+	endpoint.Properties.Kind = kind
+	endpoint.Properties.Name = name
+	endpoint.Properties.Host = host
+	endpoint.Properties.Path = path
+	endpoint.Properties.Port = port
+	endpoint.Properties.TLS = tls
+	endpoint.Properties.Public = public
+	endpoint.Links.ExposedInEnvironmentUID = NewEnvironmentUID(customerID, applicationID, environment)
+	if artifactID != "" {
+		endpoint.Links.RoutesToArtifactUID = NewArtifactUID(customerID, artifactID)
+	}
+	return endpoint
+}
@@ -21,6 +21,7 @@ type Environment struct {
 
 	Links struct {
 		EnvironmentOfApplicationUID ApplicationUID `bson:"environment_of_application_uid" json:"environmentOf"`
+		RunsInClusterUIDs           []ClusterUID   `bson:"runs_in_cluster_uids" json:"runsIn,omitempty"`
 	} `bson:"links" json:"links"`
 }
 
@@ -28,11 +29,29 @@ func NewEnvironmentUID(customerID, applicationID, environment string) Environmen
 	return EnvironmentUID(fmt.Sprintf("%v/%v", NewApplicationUID(customerID, applicationID), environment))
 }
 
-func NewEnvironment(customerID, applicationID, name string) Environment {
+func NewEnvironment(customerID, applicationID, name, cluster string) Environment {
 	environment := Environment{}
 	environment.UID = NewEnvironmentUID(customerID, applicationID, name)
 	environment.Type = EnvironmentType
 	environment.Properties.Name = name
 	environment.Links.EnvironmentOfApplicationUID = NewApplicationUID(customerID, applicationID)
+	environment.Links.RunsInClusterUIDs = []ClusterUID{NewClusterUID(cluster)}
 	return environment
 }
+
+// MergeEnvironmentClusters returns the next environment with the clusters of the previous environment that it does not already have added before its own,
+// which is how the clusters of a stored environment are updated, since an environment can run in multiple clusters
+func MergeEnvironmentClusters(previous, next Environment) Environment {
+	var clusters []ClusterUID
+	known := map[ClusterUID]struct{}{}
+	for _, list := range [][]ClusterUID{previous.Links.RunsInClusterUIDs, next.Links.RunsInClusterUIDs} {
+		for _, cluster := range list {
+			if _, ok := known[cluster]; !ok {
+				known[cluster] = struct{}{}
+				clusters = append(clusters, cluster)
+			}
+		}
+	}
+	next.Links.RunsInClusterUIDs = clusters
+	return next
+}
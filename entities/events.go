@@ -21,6 +21,11 @@ type Event struct {
 		FirstTime time.Time `bson:"first_time" json:"firstTime"`
 		LastTime  time.Time `bson:"last_time" json:"lastTime"`
 		Platform  bool      `bson:"platform" json:"platform"`
+		Container string    `bson:"container,omitempty" json:"container,omitempty"`
+		Reason    string    `bson:"reason,omitempty" json:"reason,omitempty"`
+		ExitCode  *int      `bson:"exit_code,omitempty" json:"exitCode,omitempty"`
+		Signal    int       `bson:"signal,omitempty" json:"signal,omitempty"`
+		Digest    string    `bson:"digest,omitempty" json:"digest,omitempty"`
 	} `bson:"properties" json:"properties"`
 
 	Links struct {
@@ -93,3 +98,47 @@ func NewRestartEvent(podID string, count int, firstTime, lastTime time.Time, pla
 		instance,
 	)
 }
+
+var ContainerTerminatedEventType = "ContainerTerminatedEvent"
+
+func NewKubernetesContainerTerminatedEventUID(podID, container string, finished time.Time) EventUID {
+	return EventUID(fmt.Sprintf("kubernetes/pod/%v/container/%v/terminated/%v", podID, container, finished.Unix()))
+}
+
+func NewContainerTerminatedEvent(podID, container string, started, finished time.Time, reason string, exitCode, signal int, platform bool, instance DeploymentInstanceUID) Event {
+	event := newEvent(
+		NewKubernetesContainerTerminatedEventUID(podID, container, finished),
+		ContainerTerminatedEventType,
+		1,
+		started,
+		finished,
+		platform,
+		instance,
+	)
+	event.Properties.Container = container
+	event.Properties.Reason = reason
+	event.Properties.ExitCode = &exitCode
+	event.Properties.Signal = signal
+	return event
+}
+
+var ArtifactTagMovedEventType = "ArtifactTagMovedEvent"
+
+func NewKubernetesArtifactTagMovedEventUID(podID, digest string) EventUID {
+	return EventUID(fmt.Sprintf("kubernetes/pod/%v/tag-moved/%v", podID, digest))
+}
+
+func NewArtifactTagMovedEvent(podID, container, digest string, detected time.Time, instance DeploymentInstanceUID) Event {
+	event := newEvent(
+		NewKubernetesArtifactTagMovedEventUID(podID, digest),
+		ArtifactTagMovedEventType,
+		1,
+		detected,
+		detected,
+		false,
+		instance,
+	)
+	event.Properties.Container = container
+	event.Properties.Digest = digest
+	return event
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type ImageReleaseUID string
+
+var ImageReleaseType = "ImageRelease"
+
+type ImageRelease struct {
+	UID  ImageReleaseUID `bson:"_id" json:"uid"`
+	Type string          `bson:"_type" json:"type"`
+
+	Properties struct {
+		Image    string     `bson:"image" json:"image"`
+		Released *time.Time `bson:"released" json:"released,omitempty"`
+		Resolved time.Time  `bson:"resolved" json:"resolved"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+	} `bson:"links" json:"-"`
+}
+
+func NewImageReleaseUID(image string) ImageReleaseUID {
+	return ImageReleaseUID(fmt.Sprintf("%v", image))
+}
+
+func NewImageRelease(image string, released *time.Time, resolved time.Time) ImageRelease {
+	release := ImageRelease{}
+	release.UID = NewImageReleaseUID(image)
+	release.Type = ImageReleaseType
+	release.Properties.Image = image
+	release.Properties.Released = released
+	release.Properties.Resolved = resolved
+	return release
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type CustomerNameUID string
+
+var CustomerNameType = "CustomerName"
+
+type CustomerName struct {
+	UID  CustomerNameUID `bson:"_id" json:"uid"`
+	Type string          `bson:"_type" json:"type"`
+
+	Properties struct {
+		Name string     `bson:"name" json:"name"`
+		From time.Time  `bson:"from" json:"from"`
+		To   *time.Time `bson:"to" json:"to,omitempty"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+		NameOfCustomerUID CustomerUID `bson:"name_of_customer_uid" json:"nameOf"`
+	} `bson:"links" json:"links"`
+}
+
+func NewCustomerNameUID(customerID string, from time.Time) CustomerNameUID {
+	return CustomerNameUID(fmt.Sprintf("%v/name/%v", NewCustomerUID(customerID), from.Unix()))
+}
+
+func NewCustomerName(customerID, name string, from time.Time) CustomerName {
+	customerName := CustomerName{}
+	customerName.UID = NewCustomerNameUID(customerID, from)
+	customerName.Type = CustomerNameType
+	customerName.Properties.Name = name
+	customerName.Properties.From = from
+	customerName.Links.NameOfCustomerUID = NewCustomerUID(customerID)
+	return customerName
+}
+
+type ApplicationNameUID string
+
+var ApplicationNameType = "ApplicationName"
+
+type ApplicationName struct {
+	UID  ApplicationNameUID `bson:"_id" json:"uid"`
+	Type string             `bson:"_type" json:"type"`
+
+	Properties struct {
+		Name string     `bson:"name" json:"name"`
+		From time.Time  `bson:"from" json:"from"`
+		To   *time.Time `bson:"to" json:"to,omitempty"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+		NameOfApplicationUID ApplicationUID `bson:"name_of_application_uid" json:"nameOf"`
+	} `bson:"links" json:"links"`
+}
+
+func NewApplicationNameUID(customerID, applicationID string, from time.Time) ApplicationNameUID {
+	return ApplicationNameUID(fmt.Sprintf("%v/name/%v", NewApplicationUID(customerID, applicationID), from.Unix()))
+}
+
+func NewApplicationName(customerID, applicationID, name string, from time.Time) ApplicationName {
+	applicationName := ApplicationName{}
+	applicationName.UID = NewApplicationNameUID(customerID, applicationID, from)
+	applicationName.Type = ApplicationNameType
+	applicationName.Properties.Name = name
+	applicationName.Properties.From = from
+	applicationName.Links.NameOfApplicationUID = NewApplicationUID(customerID, applicationID)
+	return applicationName
+}
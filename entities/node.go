@@ -11,30 +11,58 @@ type NodeUID string
 
 var NodeType = "Node"
 
+type NodeInfo struct {
+	Provider         string `bson:"provider" json:"provider"`
+	Region           string `bson:"region" json:"region"`
+	Zone             string `bson:"zone" json:"zone"`
+	OS               string `bson:"os" json:"os"`
+	OSImage          string `bson:"os_image" json:"osImage"`
+	Architecture     string `bson:"architecture" json:"architecture"`
+	Kernel           string `bson:"kernel" json:"kernel"`
+	Kubelet          string `bson:"kubelet" json:"kubelet"`
+	ContainerRuntime string `bson:"container_runtime" json:"containerRuntime"`
+}
+
+type NodeCapacity struct {
+	CPU    int64 `bson:"cpu" json:"cpu"`
+	Memory int64 `bson:"memory" json:"memory"`
+	Pods   int64 `bson:"pods" json:"pods"`
+}
+
 type Node struct {
-	UID  NodeUID `bson:"_id" json:"uid""`
+	UID  NodeUID `bson:"_id" json:"uid"`
 	Type string  `bson:"_type" json:"type"`
 
 	Properties struct {
 		Hostname string `bson:"hostname" json:"hostname"`
 		Image    string `bson:"image" json:"image"`
 		Type     string `bson:"type" json:"type"`
+		NodeInfo `bson:",inline"`
+		Capacity NodeCapacity `bson:"capacity" json:"capacity"`
 	} `bson:"properties" json:"properties"`
 
-	Link struct {
-	} `bson:"links" json:"-"`
+	Links struct {
+		PartOfClusterUID    ClusterUID  `bson:"part_of_cluster_uid" json:"partOf"`
+		MemberOfNodePoolUID NodePoolUID `bson:"member_of_node_pool_uid" json:"memberOf,omitempty"`
+	} `bson:"links" json:"links"`
 }
 
-func NewNodeUID(nodename string) NodeUID {
-	return NodeUID(fmt.Sprintf("%v", nodename))
+func NewNodeUID(cluster, nodename string) NodeUID {
+	return NodeUID(fmt.Sprintf("%v/%v", NewClusterUID(cluster), nodename))
 }
 
-func NewNode(nodename, hostname, image, nodetype string) Node {
+func NewNode(cluster, nodename, hostname, image, nodetype string, info NodeInfo, capacity NodeCapacity, poolName string) Node {
 	node := Node{}
-	node.UID = NewNodeUID(nodename)
+	node.UID = NewNodeUID(cluster, nodename)
 	node.Type = NodeType
 	node.Properties.Hostname = hostname
 	node.Properties.Image = image
 	node.Properties.Type = nodetype
+	node.Properties.NodeInfo = info
+	node.Properties.Capacity = capacity
+	node.Links.PartOfClusterUID = NewClusterUID(cluster)
+	if poolName != "" {
+		node.Links.MemberOfNodePoolUID = NewNodePoolUID(cluster, poolName)
+	}
 	return node
 }
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type NodeEventUID string
+
+type NodeEvent struct {
+	UID  NodeEventUID `bson:"_id" json:"uid"`
+	Type string       `bson:"_type" json:"type"`
+
+	Properties struct {
+		Started time.Time  `bson:"started" json:"started"`
+		Ended   *time.Time `bson:"ended" json:"ended,omitempty"`
+		Reason  string     `bson:"reason,omitempty" json:"reason,omitempty"`
+		From    string     `bson:"from,omitempty" json:"from,omitempty"`
+		To      string     `bson:"to,omitempty" json:"to,omitempty"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+		HappenedToNodeUID NodeUID `bson:"happened_to_node_uid" json:"happenedTo"`
+	} `bson:"links" json:"links"`
+}
+
+var (
+	NodeNotReadyEventType           = "NodeNotReadyEvent"
+	NodeMemoryPressureEventType     = "NodeMemoryPressureEvent"
+	NodeDiskPressureEventType       = "NodeDiskPressureEvent"
+	NodePIDPressureEventType        = "NodePIDPressureEvent"
+	NodeNetworkUnavailableEventType = "NodeNetworkUnavailableEvent"
+	NodeUnschedulableEventType      = "NodeUnschedulableEvent"
+	NodeImageUpgradedEventType      = "NodeImageUpgradedEvent"
+)
+
+func NewNodeEventUID(node NodeUID, eventType string, started time.Time) NodeEventUID {
+	return NodeEventUID(fmt.Sprintf("kubernetes/node/%v/%v/%v", node, strings.TrimSuffix(eventType, "Event"), started.Unix()))
+}
+
+func NewNodeEvent(node NodeUID, eventType string, started time.Time, ended *time.Time, reason string) NodeEvent {
+	event := NodeEvent{}
+	event.UID = NewNodeEventUID(node, eventType, started)
+	event.Type = eventType
+	event.Properties.Started = started
+	event.Properties.Ended = ended
+	event.Properties.Reason = reason
+	event.Links.HappenedToNodeUID = node
+	return event
+}
+
+func NewNodeImageUpgradedEvent(node NodeUID, from, to string, upgraded time.Time) NodeEvent {
+	event := NewNodeEvent(node, NodeImageUpgradedEventType, upgraded, &upgraded, "")
+	event.Properties.From = from
+	event.Properties.To = to
+	return event
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package entities
+
+import "fmt"
+
+type NodePoolUID string
+
+var NodePoolType = "NodePool"
+
+type NodePool struct {
+	UID  NodePoolUID `bson:"_id" json:"uid"`
+	Type string      `bson:"_type" json:"type"`
+
+	Properties struct {
+		Name     string `bson:"name" json:"name"`
+		Provider string `bson:"provider" json:"provider"`
+		Mode     string `bson:"mode" json:"mode,omitempty"`
+		VMSize   string `bson:"vm_size" json:"vmSize,omitempty"`
+		MinSize  *int   `bson:"min_size" json:"minSize,omitempty"`
+		MaxSize  *int   `bson:"max_size" json:"maxSize,omitempty"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+	} `bson:"links" json:"-"`
+}
+
+func NewNodePoolUID(cluster, name string) NodePoolUID {
+	return NodePoolUID(fmt.Sprintf("%v/%v", NewClusterUID(cluster), name))
+}
+
+func NewNodePool(cluster, name, provider, mode, vmSize string, minSize, maxSize *int) NodePool {
+	pool := NodePool{}
+	pool.UID = NewNodePoolUID(cluster, name)
+	pool.Type = NodePoolType
+	pool.Properties.Name = name
+	pool.Properties.Provider = provider
+	pool.Properties.Mode = mode
+	pool.Properties.VMSize = vmSize
+	pool.Properties.MinSize = minSize
+	pool.Properties.MaxSize = maxSize
+	return pool
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package entities
+
+type ContainerResources struct {
+	CPURequest    int64 `bson:"cpu_request" json:"cpuRequest"`
+	CPULimit      int64 `bson:"cpu_limit" json:"cpuLimit"`
+	MemoryRequest int64 `bson:"memory_request" json:"memoryRequest"`
+	MemoryLimit   int64 `bson:"memory_limit" json:"memoryLimit"`
+}
+
+type ResourceProfile struct {
+	Runtime ContainerResources `bson:"runtime" json:"runtime"`
+	Head    ContainerResources `bson:"head" json:"head"`
+}
+
+func NewContainerResources(cpuRequest, cpuLimit, memoryRequest, memoryLimit int64) ContainerResources {
+	return ContainerResources{
+		CPURequest:    cpuRequest,
+		CPULimit:      cpuLimit,
+		MemoryRequest: memoryRequest,
+		MemoryLimit:   memoryLimit,
+	}
+}
+
+func NewResourceProfile(runtime, head ContainerResources) ResourceProfile {
+	return ResourceProfile{
+		Runtime: runtime,
+		Head:    head,
+	}
+}
@@ -19,32 +19,47 @@ type RuntimeVersion struct {
 	Type string            `bson:"_type" json:"type"`
 
 	Properties struct {
-		Major      int       `bson:"major" json:"major"`
-		Minor      int       `bson:"minor" json:"minor"`
-		Patch      int       `bson:"patch" json:"patch"`
-		Prerelease string    `bson:"prerelease" json:"prerelease,omitempty"`
-		Released   time.Time `bson:"released" json:"-"`
+		Major      int        `bson:"major" json:"major"`
+		Minor      int        `bson:"minor" json:"minor"`
+		Patch      int        `bson:"patch" json:"patch"`
+		Prerelease string     `bson:"prerelease" json:"prerelease,omitempty"`
+		Build      string     `bson:"build" json:"build,omitempty"`
+		Released   *time.Time `bson:"released" json:"released,omitempty"`
 	} `bson:"properties" json:"properties"`
 
 	Links struct {
 	} `bson:"links" json:"-"`
 }
 
-func NewRuntimeVersionUID(major, minor, patch int, prerelease string) RuntimeVersionUID {
-	if prerelease == "" {
-		return RuntimeVersionUID(fmt.Sprintf("%v.%v.%v", major, minor, patch))
+func NewRuntimeVersionUID(major, minor, patch int, prerelease, build string) RuntimeVersionUID {
+	uid := fmt.Sprintf("%v.%v.%v", major, minor, patch)
+	if prerelease != "" {
+		uid += "-" + prerelease
 	}
-	return RuntimeVersionUID(fmt.Sprintf("%v.%v.%v-%v", major, minor, patch, prerelease))
+	if build != "" {
+		uid += "+" + build
+	}
+	return RuntimeVersionUID(uid)
 }
 
-func NewRuntimeVersion(major, minor, patch int, prerelease string, released time.Time) RuntimeVersion {
+func NewRuntimeVersion(major, minor, patch int, prerelease, build string, released *time.Time) RuntimeVersion {
 	version := RuntimeVersion{}
-	version.UID = NewRuntimeVersionUID(major, minor, patch, prerelease)
+	version.UID = NewRuntimeVersionUID(major, minor, patch, prerelease, build)
 	version.Type = RuntimeVersionType
 	version.Properties.Major = major
 	version.Properties.Minor = minor
 	version.Properties.Patch = patch
 	version.Properties.Prerelease = prerelease
+	version.Properties.Build = build
 	version.Properties.Released = released
 	return version
 }
+
+var UnknownRuntimeVersionUID = RuntimeVersionUID("unknown")
+
+func NewUnknownRuntimeVersion() RuntimeVersion {
+	version := RuntimeVersion{}
+	version.UID = UnknownRuntimeVersionUID
+	version.Type = RuntimeVersionType
+	return version
+}
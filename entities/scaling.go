@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type AutoscalerUID string
+
+var AutoscalerType = "Autoscaler"
+
+type Autoscaler struct {
+	UID  AutoscalerUID `bson:"_id" json:"uid"`
+	Type string        `bson:"_type" json:"type"`
+
+	Properties struct {
+		Name         string `bson:"name" json:"name"`
+		MinReplicas  int    `bson:"min_replicas" json:"minReplicas"`
+		MaxReplicas  int    `bson:"max_replicas" json:"maxReplicas"`
+		TargetCPU    *int   `bson:"target_cpu" json:"targetCpu,omitempty"`
+		TargetMemory *int   `bson:"target_memory" json:"targetMemory,omitempty"`
+		// Replicas and LastScaled are the current number of replicas and the last scale time from the status of the autoscaler when it was last observed
+		Replicas   int        `bson:"replicas" json:"replicas"`
+		LastScaled *time.Time `bson:"last_scaled" json:"lastScaled,omitempty"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+		ScalesDeploymentUID DeploymentUID `bson:"scales_deployment_uid" json:"scales"`
+	} `bson:"links" json:"links"`
+}
+
+func NewAutoscalerUID(deployment DeploymentUID, name string) AutoscalerUID {
+	return AutoscalerUID(fmt.Sprintf("%v/autoscaler/%v", deployment, name))
+}
+
+func NewAutoscaler(deployment DeploymentUID, name string, minReplicas, maxReplicas int, targetCPU, targetMemory *int, replicas int, lastScaled *time.Time) Autoscaler {
+	autoscaler := Autoscaler{}
+	autoscaler.UID = NewAutoscalerUID(deployment, name)
+	autoscaler.Type = AutoscalerType
+	autoscaler.Properties.Name = name
+	autoscaler.Properties.MinReplicas = minReplicas
+	autoscaler.Properties.MaxReplicas = maxReplicas
+	autoscaler.Properties.TargetCPU = targetCPU
+	autoscaler.Properties.TargetMemory = targetMemory
+	autoscaler.Properties.Replicas = replicas
+	autoscaler.Properties.LastScaled = lastScaled
+	autoscaler.Links.ScalesDeploymentUID = deployment
+	return autoscaler
+}
+
+type ScaledEventUID string
+
+var ScaledEventType = "ScaledEvent"
+
+type ScaledEvent struct {
+	UID  ScaledEventUID `bson:"_id" json:"uid"`
+	Type string         `bson:"_type" json:"type"`
+
+	Properties struct {
+		Time   time.Time `bson:"time" json:"time"`
+		From   *int      `bson:"from" json:"from,omitempty"`
+		To     int       `bson:"to" json:"to"`
+		Reason string    `bson:"reason" json:"reason,omitempty"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+		HappenedToDeploymentUID DeploymentUID `bson:"happened_to_deployment_uid" json:"happenedTo"`
+	} `bson:"links" json:"links"`
+}
+
+func NewScaledEventUID(deployment DeploymentUID, scaled time.Time) ScaledEventUID {
+	return ScaledEventUID(fmt.Sprintf("%v/scaled/%v", deployment, scaled.Unix()))
+}
+
+func NewScaledEvent(deployment DeploymentUID, scaled time.Time, from *int, to int, reason string) ScaledEvent {
+	event := ScaledEvent{}
+	event.UID = NewScaledEventUID(deployment, scaled)
+	event.Type = ScaledEventType
+	event.Properties.Time = scaled
+	event.Properties.From = from
+	event.Properties.To = to
+	event.Properties.Reason = reason
+	event.Links.HappenedToDeploymentUID = deployment
+	return event
+}
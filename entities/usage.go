@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type UsageAggregate struct {
+	Min int64 `bson:"min" json:"min"`
+	Avg int64 `bson:"avg" json:"avg"`
+	Max int64 `bson:"max" json:"max"`
+	P95 int64 `bson:"p95" json:"p95"`
+}
+
+type ResourceUsageUID string
+
+var ResourceUsageType = "ResourceUsage"
+
+type ResourceUsage struct {
+	UID  ResourceUsageUID `bson:"_id" json:"uid"`
+	Type string           `bson:"_type" json:"type"`
+
+	Properties struct {
+		Container string         `bson:"container" json:"container"`
+		From      time.Time      `bson:"from" json:"from"`
+		To        time.Time      `bson:"to" json:"to"`
+		Samples   int            `bson:"samples" json:"samples"`
+		CPU       UsageAggregate `bson:"cpu" json:"cpu"`
+		Memory    UsageAggregate `bson:"memory" json:"memory"`
+	} `bson:"properties" json:"properties"`
+
+	Links struct {
+		MeasuredOnDeploymentInstanceUID DeploymentInstanceUID `bson:"measured_on_deployment_instance_uid" json:"measuredOn"`
+	} `bson:"links" json:"links"`
+}
+
+func NewResourceUsageUID(instance DeploymentInstanceUID, container string, from time.Time) ResourceUsageUID {
+	return ResourceUsageUID(fmt.Sprintf("%v/%v/usage/%v", instance, container, from.Unix()))
+}
+
+func NewResourceUsage(instance DeploymentInstanceUID, container string, from, to time.Time, samples int, cpu, memory UsageAggregate) ResourceUsage {
+	usage := ResourceUsage{}
+	usage.UID = NewResourceUsageUID(instance, container, from)
+	usage.Type = ResourceUsageType
+	usage.Properties.Container = container
+	usage.Properties.From = from
+	usage.Properties.To = to
+	usage.Properties.Samples = samples
+	usage.Properties.CPU = cpu
+	usage.Properties.Memory = memory
+	usage.Links.MeasuredOnDeploymentInstanceUID = instance
+	return usage
+}
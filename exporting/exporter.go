@@ -41,6 +41,16 @@ func (e *Exporter) ExportToFile(path string) error {
 
 	var data []any
 
+	clusters, err := e.repositories.Clusters.List()
+	if err != nil {
+		e.logger.Error().Err(err).Msg("Failed to get clusters")
+		return err
+	}
+	for _, cluster := range clusters {
+		cluster.UID = entities.ClusterUID(fmt.Sprintf("%v:%v", entities.ClusterType, cluster.UID))
+		data = append(data, cluster)
+	}
+
 	nodes, err := e.repositories.Nodes.List()
 	if err != nil {
 		e.logger.Error().Err(err).Msg("Failed to get nodes")
@@ -48,9 +58,34 @@ func (e *Exporter) ExportToFile(path string) error {
 	}
 	for _, node := range nodes {
 		node.UID = entities.NodeUID(fmt.Sprintf("%v:%v", entities.NodeType, node.UID))
+		node.Links.PartOfClusterUID = entities.ClusterUID(fmt.Sprintf("%v:%v", entities.ClusterType, node.Links.PartOfClusterUID))
+		if node.Links.MemberOfNodePoolUID != "" {
+			node.Links.MemberOfNodePoolUID = entities.NodePoolUID(fmt.Sprintf("%v:%v", entities.NodePoolType, node.Links.MemberOfNodePoolUID))
+		}
 		data = append(data, node)
 	}
 
+	nodePools, err := e.repositories.Nodes.ListPools()
+	if err != nil {
+		e.logger.Error().Err(err).Msg("Failed to get node pools")
+		return err
+	}
+	for _, pool := range nodePools {
+		pool.UID = entities.NodePoolUID(fmt.Sprintf("%v:%v", entities.NodePoolType, pool.UID))
+		data = append(data, pool)
+	}
+
+	nodeEvents, err := e.repositories.Nodes.ListEvents()
+	if err != nil {
+		e.logger.Error().Err(err).Msg("Failed to get node events")
+		return err
+	}
+	for _, event := range nodeEvents {
+		event.UID = entities.NodeEventUID(fmt.Sprintf("%v:%v", event.Type, event.UID))
+		event.Links.HappenedToNodeUID = entities.NodeUID(fmt.Sprintf("%v:%v", entities.NodeType, event.Links.HappenedToNodeUID))
+		data = append(data, event)
+	}
+
 	customers, err := e.repositories.Customers.List()
 	if err != nil {
 		e.logger.Error().Err(err).Msg("Failed to get customers")
@@ -61,6 +96,17 @@ func (e *Exporter) ExportToFile(path string) error {
 		data = append(data, customer)
 	}
 
+	customerNames, err := e.repositories.Customers.ListNames()
+	if err != nil {
+		e.logger.Error().Err(err).Msg("Failed to get customer names")
+		return err
+	}
+	for _, name := range customerNames {
+		name.UID = entities.CustomerNameUID(fmt.Sprintf("%v:%v", entities.CustomerNameType, name.UID))
+		name.Links.NameOfCustomerUID = entities.CustomerUID(fmt.Sprintf("%v:%v", entities.CustomerType, name.Links.NameOfCustomerUID))
+		data = append(data, name)
+	}
+
 	applications, err := e.repositories.Applications.List()
 	if err != nil {
 		e.logger.Error().Err(err).Msg("Failed to get applications")
@@ -72,6 +118,17 @@ func (e *Exporter) ExportToFile(path string) error {
 		data = append(data, application)
 	}
 
+	applicationNames, err := e.repositories.Applications.ListNames()
+	if err != nil {
+		e.logger.Error().Err(err).Msg("Failed to get application names")
+		return err
+	}
+	for _, name := range applicationNames {
+		name.UID = entities.ApplicationNameUID(fmt.Sprintf("%v:%v", entities.ApplicationNameType, name.UID))
+		name.Links.NameOfApplicationUID = entities.ApplicationUID(fmt.Sprintf("%v:%v", entities.ApplicationType, name.Links.NameOfApplicationUID))
+		data = append(data, name)
+	}
+
 	environments, err := e.repositories.Environments.List()
 	if err != nil {
 		e.logger.Error().Err(err).Msg("Failed to get environments")
@@ -80,6 +137,9 @@ func (e *Exporter) ExportToFile(path string) error {
 	for _, environment := range environments {
 		environment.UID = entities.EnvironmentUID(fmt.Sprintf("%v:%v", entities.EnvironmentType, environment.UID))
 		environment.Links.EnvironmentOfApplicationUID = entities.ApplicationUID(fmt.Sprintf("%v:%v", entities.ApplicationType, environment.Links.EnvironmentOfApplicationUID))
+		for i, cluster := range environment.Links.RunsInClusterUIDs {
+			environment.Links.RunsInClusterUIDs[i] = entities.ClusterUID(fmt.Sprintf("%v:%v", entities.ClusterType, cluster))
+		}
 		data = append(data, environment)
 	}
 
@@ -94,6 +154,20 @@ func (e *Exporter) ExportToFile(path string) error {
 		data = append(data, artifact)
 	}
 
+	endpoints, err := e.repositories.Endpoints.List()
+	if err != nil {
+		e.logger.Error().Err(err).Msg("Failed to get endpoints")
+		return err
+	}
+	for _, endpoint := range endpoints {
+		endpoint.UID = entities.EndpointUID(fmt.Sprintf("%v:%v", entities.EndpointType, endpoint.UID))
+		endpoint.Links.ExposedInEnvironmentUID = entities.EnvironmentUID(fmt.Sprintf("%v:%v", entities.EnvironmentType, endpoint.Links.ExposedInEnvironmentUID))
+		if endpoint.Links.RoutesToArtifactUID != "" {
+			endpoint.Links.RoutesToArtifactUID = entities.ArtifactUID(fmt.Sprintf("%v:%v", entities.ArtifactType, endpoint.Links.RoutesToArtifactUID))
+		}
+		data = append(data, endpoint)
+	}
+
 	artifactVersions, err := e.repositories.Artifacts.ListVersions()
 	if err != nil {
 		e.logger.Error().Err(err).Msg("Failed to get artifact versions")
@@ -128,6 +202,28 @@ func (e *Exporter) ExportToFile(path string) error {
 		data = append(data, deployment)
 	}
 
+	autoscalers, err := e.repositories.Autoscalers.List()
+	if err != nil {
+		e.logger.Error().Err(err).Msg("Failed to get autoscalers")
+		return err
+	}
+	for _, autoscaler := range autoscalers {
+		autoscaler.UID = entities.AutoscalerUID(fmt.Sprintf("%v:%v", entities.AutoscalerType, autoscaler.UID))
+		autoscaler.Links.ScalesDeploymentUID = entities.DeploymentUID(fmt.Sprintf("%v:%v", entities.DeploymentType, autoscaler.Links.ScalesDeploymentUID))
+		data = append(data, autoscaler)
+	}
+
+	scaledEvents, err := e.repositories.Autoscalers.ListEvents()
+	if err != nil {
+		e.logger.Error().Err(err).Msg("Failed to get scaled events")
+		return err
+	}
+	for _, event := range scaledEvents {
+		event.UID = entities.ScaledEventUID(fmt.Sprintf("%v:%v", entities.ScaledEventType, event.UID))
+		event.Links.HappenedToDeploymentUID = entities.DeploymentUID(fmt.Sprintf("%v:%v", entities.DeploymentType, event.Links.HappenedToDeploymentUID))
+		data = append(data, event)
+	}
+
 	artifactConfigs, err := e.repositories.Configurations.ListArtifacts()
 	if err != nil {
 		e.logger.Error().Err(err).Msg("Failed to get artifact configurations")
@@ -148,6 +244,17 @@ func (e *Exporter) ExportToFile(path string) error {
 		data = append(data, config)
 	}
 
+	nodeConfigs, err := e.repositories.Configurations.ListNodes()
+	if err != nil {
+		e.logger.Error().Err(err).Msg("Failed to get node configurations")
+		return err
+	}
+	for _, config := range nodeConfigs {
+		config.UID = entities.NodeConfigurationUID(fmt.Sprintf("%v:%v", entities.NodeConfigurationType, config.UID))
+		config.Links.ConfigurationOfNodeUID = entities.NodeUID(fmt.Sprintf("%v:%v", entities.NodeType, config.Links.ConfigurationOfNodeUID))
+		data = append(data, config)
+	}
+
 	instances, err := e.repositories.Deployments.ListInstances()
 	if err != nil {
 		e.logger.Error().Err(err).Msg("Failed to get deployment instances")
@@ -159,6 +266,9 @@ func (e *Exporter) ExportToFile(path string) error {
 		instance.Links.UsesArtifactConfigurationUID = entities.ArtifactConfigurationUID(fmt.Sprintf("%v:%v", entities.ArtifactConfigurationType, instance.Links.UsesArtifactConfigurationUID))
 		instance.Links.UsesRuntimeConfigurationUID = entities.RuntimeConfigurationUID(fmt.Sprintf("%v:%v", entities.RuntimeConfigurationType, instance.Links.UsesRuntimeConfigurationUID))
 		instance.Links.ScheduledOnNodeUID = entities.NodeUID(fmt.Sprintf("%v:%v", entities.NodeType, instance.Links.ScheduledOnNodeUID))
+		if instance.Links.RanOnNodeConfigurationUID != "" {
+			instance.Links.RanOnNodeConfigurationUID = entities.NodeConfigurationUID(fmt.Sprintf("%v:%v", entities.NodeConfigurationType, instance.Links.RanOnNodeConfigurationUID))
+		}
 		data = append(data, instance)
 	}
 
@@ -173,6 +283,17 @@ func (e *Exporter) ExportToFile(path string) error {
 		data = append(data, event)
 	}
 
+	usages, err := e.repositories.Usages.List()
+	if err != nil {
+		e.logger.Error().Err(err).Msg("Failed to get resource usages")
+		return err
+	}
+	for _, usage := range usages {
+		usage.UID = entities.ResourceUsageUID(fmt.Sprintf("%v:%v", entities.ResourceUsageType, usage.UID))
+		usage.Links.MeasuredOnDeploymentInstanceUID = entities.DeploymentInstanceUID(fmt.Sprintf("%v:%v", entities.DeploymentInstanceType, usage.Links.MeasuredOnDeploymentInstanceUID))
+		data = append(data, usage)
+	}
+
 	e.logger.Info().Msg("Writing to file...")
 	for _, entry := range data {
 		encoded, err := json.Marshal(entry)
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package kubernetes
+
+import (
+	"context"
+	authorizationV1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// AccessReviewer checks what the observer is allowed to do using SelfSubjectAccessReviews, and which resources the API server serves using discovery
+type AccessReviewer struct {
+	client kubernetes.Interface
+	ctx    context.Context
+}
+
+func NewAccessReviewer(client kubernetes.Interface, ctx context.Context) *AccessReviewer {
+	return &AccessReviewer{
+		client: client,
+		ctx:    ctx,
+	}
+}
+
+// Can returns true if the observer is allowed to perform the verb on the resource in the namespace, or cluster-wide if the namespace is empty
+func (a *AccessReviewer) Can(verb, group, resource, namespace string) (bool, error) {
+	review := &authorizationV1.SelfSubjectAccessReview{
+		Spec: authorizationV1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationV1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     group,
+				Resource:  resource,
+			},
+		},
+	}
+
+	result, err := a.client.AuthorizationV1().SelfSubjectAccessReviews().Create(a.ctx, review, metaV1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return result.Status.Allowed, nil
+}
+
+// CanListAndWatch returns true if the observer is allowed to both list and watch the resource in the namespace.
+// A nil AccessReviewer allows everything, which is used to find the required permissions without a cluster.
+func (a *AccessReviewer) CanListAndWatch(group, resource, namespace string) (bool, error) {
+	if a == nil {
+		return true, nil
+	}
+
+	for _, verb := range []string{"list", "watch"} {
+		allowed, err := a.Can(verb, group, resource, namespace)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Serves returns true if the API server serves the resource in the group version, which is false for API versions that are newer than the cluster.
+// A nil AccessReviewer assumes that everything is served, which is used to find the required permissions without a cluster.
+func (a *AccessReviewer) Serves(groupVersion, resource string) (bool, error) {
+	if a == nil {
+		return true, nil
+	}
+
+	resources, err := a.client.Discovery().ServerResourcesForGroupVersion(groupVersion)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, served := range resources.APIResources {
+		if served.Name == resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -6,18 +6,25 @@
 package kubernetes
 
 import (
+	"context"
+	"fmt"
 	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
-// NewClientUsing creates a new Kubernetes client using the provided config
-func NewClientUsing(config *koanf.Koanf) (kubernetes.Interface, error) {
-	rules := clientcmd.NewDefaultClientConfigLoadingRules()
-	overrides := &clientcmd.ConfigOverrides{}
-	loader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+// DefaultClusterName is the name used for the replayed cluster when none is provided
+const DefaultClusterName = "default"
 
-	kubernetesConfig, err := loader.ClientConfig()
+// NewClientUsing creates a new Kubernetes client using the provided config and kubeconfig context
+func NewClientUsing(config *koanf.Koanf, context string) (kubernetes.Interface, error) {
+	kubernetesConfig, err := loadConfigUsing(config, context)
 	if err != nil {
 		return nil, err
 	}
@@ -29,3 +36,171 @@ func NewClientUsing(config *koanf.Koanf) (kubernetes.Interface, error) {
 
 	return client, nil
 }
+
+// NewMetricsClientUsing creates a new Kubernetes metrics.k8s.io client using the provided config and kubeconfig context
+func NewMetricsClientUsing(config *koanf.Koanf, context string) (metrics.Interface, error) {
+	kubernetesConfig, err := loadConfigUsing(config, context)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := metrics.NewForConfig(kubernetesConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// GetContextsUsing returns the kubeconfig contexts to observe from the provided config.
+// An empty context means the current context of the kubeconfig, or the in-cluster config.
+func GetContextsUsing(config *koanf.Koanf) []string {
+	contexts := config.Strings("kubernetes.contexts")
+	if len(contexts) == 0 {
+		return []string{config.String("kubernetes.context")}
+	}
+	return contexts
+}
+
+// ClusterInfo describes the cluster that a kubeconfig context connects to
+type ClusterInfo struct {
+	// Name is the configured cluster name, the context name, or the current context of the kubeconfig if none is provided
+	Name string
+	// Server is the address of the API server
+	Server string
+	// AssumedIdentity is the user the observer is assumed to authenticate as. It is guessed by the client from the unverified subject
+	// of the bearer token, or the user name of the kubeconfig context, since Kubernetes 1.24 has no API to ask the server who the user is.
+	AssumedIdentity string
+}
+
+// GetClusterUsing returns the name, API server address and identity of the cluster for the provided kubeconfig context.
+// When a single cluster is observed, the name can be set with the cluster-name config. The in-cluster config does not identify the cluster,
+// so if the name is not set, the UID of the kube-system namespace is used as a stable name instead.
+func GetClusterUsing(config *koanf.Koanf, context string, logger zerolog.Logger) (ClusterInfo, error) {
+	name := config.String("kubernetes.cluster-name")
+	if name != "" && len(config.Strings("kubernetes.contexts")) > 1 {
+		return ClusterInfo{}, ErrClusterNameWithMultipleContexts
+	}
+	if err := checkOverridesUsing(config); err != nil {
+		return ClusterInfo{}, err
+	}
+
+	loader := newLoaderUsing(config, context)
+	kubernetesConfig, err := loader.ClientConfig()
+	if err != nil {
+		return ClusterInfo{}, err
+	}
+
+	info := ClusterInfo{
+		Name:            name,
+		Server:          kubernetesConfig.Host,
+		AssumedIdentity: getIdentity(kubernetesConfig),
+	}
+
+	raw, err := loader.RawConfig()
+	if err != nil || len(raw.Contexts) == 0 {
+		// the in-cluster config is used when there is no kubeconfig
+		if info.Name == "" {
+			return nameClusterBySystemNamespace(info, kubernetesConfig, logger)
+		}
+		return info, nil
+	}
+
+	if context == "" {
+		context = raw.CurrentContext
+	}
+	if info.Name == "" {
+		info.Name = context
+	}
+	if info.Name == "" {
+		return nameClusterBySystemNamespace(info, kubernetesConfig, logger)
+	}
+	if kubeContext, ok := raw.Contexts[context]; ok && info.AssumedIdentity == "" {
+		info.AssumedIdentity = kubeContext.AuthInfo
+	}
+	return info, nil
+}
+
+// nameClusterBySystemNamespace names the cluster after the UID of the kube-system namespace, which stays the same for the lifetime of the cluster.
+// The namespace is listed instead of read, since the observer is already allowed to list namespaces.
+func nameClusterBySystemNamespace(info ClusterInfo, kubernetesConfig *rest.Config, logger zerolog.Logger) (ClusterInfo, error) {
+	client, err := kubernetes.NewForConfig(kubernetesConfig)
+	if err != nil {
+		return ClusterInfo{}, ClusterNameNotDerived(err)
+	}
+
+	namespaces, err := client.CoreV1().Namespaces().List(context.Background(), metaV1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", SystemNamespace).String(),
+	})
+	if err != nil {
+		return ClusterInfo{}, ClusterNameNotDerived(err)
+	}
+	if len(namespaces.Items) != 1 {
+		return ClusterInfo{}, ClusterNameNotDerived(fmt.Errorf("found %d %v namespaces", len(namespaces.Items), SystemNamespace))
+	}
+
+	info.Name = string(namespaces.Items[0].GetUID())
+	logger.Warn().Str("cluster", info.Name).Msg("Naming the cluster after the UID of the kube-system namespace since it cannot be derived from the kubeconfig context, set --kubernetes.cluster-name to give it a readable name")
+	return info, nil
+}
+
+func loadConfigUsing(config *koanf.Koanf, context string) (*rest.Config, error) {
+	if err := checkOverridesUsing(config); err != nil {
+		return nil, err
+	}
+
+	kubernetesConfig, err := newLoaderUsing(config, context).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if qps := config.Float64("kubernetes.qps"); qps > 0 {
+		kubernetesConfig.QPS = float32(qps)
+	}
+	if burst := config.Int("kubernetes.burst"); burst > 0 {
+		kubernetesConfig.Burst = burst
+	}
+
+	return kubernetesConfig, nil
+}
+
+// checkOverridesUsing returns an error if the API server, token or certificate authority is overridden while observing multiple contexts,
+// since the overrides would apply to the clusters of all the contexts
+func checkOverridesUsing(config *koanf.Koanf) error {
+	if len(config.Strings("kubernetes.contexts")) <= 1 {
+		return nil
+	}
+	for _, key := range []string{"kubernetes.server", "kubernetes.token-file", "kubernetes.ca-file"} {
+		if config.String(key) != "" {
+			return OverrideWithMultipleContexts(key)
+		}
+	}
+	return nil
+}
+
+func newLoaderUsing(config *koanf.Koanf, context string) clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig := config.String("kubernetes.kubeconfig"); kubeconfig != "" {
+		rules.ExplicitPath = kubeconfig
+	}
+
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: context}
+	overrides.ClusterInfo.Server = config.String("kubernetes.server")
+	overrides.ClusterInfo.CertificateAuthority = config.String("kubernetes.ca-file")
+	overrides.AuthInfo.TokenFile = config.String("kubernetes.token-file")
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+}
+
+// GetServerVersionUsing returns the Kubernetes version of the API server of the cluster.
+// If the API server does not accept the credentials, an AuthenticationFailed error is returned.
+func GetServerVersionUsing(client kubernetes.Interface, cluster ClusterInfo) (string, error) {
+	info, err := client.Discovery().ServerVersion()
+	if errors.IsUnauthorized(err) {
+		return "", &AuthenticationFailed{Cluster: cluster, Err: err}
+	}
+	if err != nil {
+		return "", err
+	}
+	return info.GitVersion, nil
+}
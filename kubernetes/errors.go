@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrClusterNameRequired             = errors.New("the cluster name must be provided with --kubernetes.cluster-name when it cannot be derived from the kubeconfig context or the kube-system namespace")
+	ErrClusterNameWithMultipleContexts = errors.New("--kubernetes.cluster-name cannot be used when observing multiple kubeconfig contexts, the clusters are named after the contexts")
+	ErrOverrideWithMultipleContexts    = errors.New("the connection overrides cannot be used when observing multiple kubeconfig contexts, since they would apply to all the clusters")
+	ErrSecretsKeyRequired              = errors.New("the key to compute the digests of Secret values must be provided with --secrets.key when recording, so that the recorded digests cannot be guessed")
+)
+
+func ClusterNameNotDerived(err error) error {
+	return fmt.Errorf("%w: %v", ErrClusterNameRequired, err)
+}
+
+func OverrideWithMultipleContexts(key string) error {
+	return fmt.Errorf("%w: --%v", ErrOverrideWithMultipleContexts, key)
+}
+
+// AuthenticationFailed is returned when the API server of a cluster does not accept the credentials of the observer
+type AuthenticationFailed struct {
+	Cluster ClusterInfo
+	Err     error
+}
+
+func (e *AuthenticationFailed) Error() string {
+	identity := "unknown identity"
+	if e.Cluster.AssumedIdentity != "" {
+		identity = e.Cluster.AssumedIdentity + " (assumed)"
+	}
+	return fmt.Sprintf("authentication to cluster %v at %v as %v failed: %v", e.Cluster.Name, e.Cluster.Server, identity, e.Err)
+}
+
+func (e *AuthenticationFailed) Unwrap() error {
+	return e.Err
+}
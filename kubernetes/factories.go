@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package kubernetes
+
+import (
+	"github.com/knadh/koanf"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SystemNamespace is the namespace where cluster components, like the cluster-autoscaler, report their status
+const SystemNamespace = "kube-system"
+
+// NamespacedFactory is an informer factory for namespaced resources, in a single namespace or in all namespaces.
+// The embedded factory is used for the workload resources (Pods, ReplicaSets, Jobs, HorizontalPodAutoscalers, Services and Ingresses), and is filtered by the label selector.
+type NamespacedFactory struct {
+	informers.SharedInformerFactory
+
+	// Unfiltered is used for the resources that are not filtered by the label selector, like ConfigMaps, Secrets, Events and the owners of the workloads
+	Unfiltered informers.SharedInformerFactory
+	// Namespace is the observed namespace, or metaV1.NamespaceAll
+	Namespace string
+	// SecretsAllowed is true if the observer is allowed to list and watch Secrets in the namespace
+	SecretsAllowed bool
+	// AutoscalersServed is true if the cluster serves autoscaling/v2 HorizontalPodAutoscalers, which are not served before Kubernetes 1.23
+	AutoscalersServed bool
+}
+
+// Factories are the informer factories that are used to observe a cluster
+type Factories struct {
+	// Cluster is used for cluster-scoped resources, like Nodes and Namespaces
+	Cluster informers.SharedInformerFactory
+	// System is used for resources in the kube-system namespace
+	System informers.SharedInformerFactory
+	// Namespaced are used for namespaced resources, one for each observed namespace
+	Namespaced []NamespacedFactory
+}
+
+// NewFactoriesUsing creates the informer factories for the namespaces and label selector in the provided config.
+// If no namespaces are configured, a single factory is used for all unfiltered resources so that informers are shared.
+func NewFactoriesUsing(config *koanf.Koanf, client kubernetes.Interface, access *AccessReviewer) (*Factories, error) {
+	resync := config.Duration("kubernetes.sync-interval")
+	selector := config.String("kubernetes.label-selector")
+	namespaces := config.Strings("kubernetes.namespaces")
+
+	autoscalersServed, err := access.Serves("autoscaling/v2", "horizontalpodautoscalers")
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := informers.NewSharedInformerFactory(client, resync)
+	factories := &Factories{
+		Cluster: cluster,
+		System:  cluster,
+	}
+
+	if len(namespaces) == 0 {
+		namespaced := cluster
+		if selector != "" {
+			namespaced = informers.NewSharedInformerFactoryWithOptions(client, resync, withLabelSelector(selector))
+		}
+
+		secretsAllowed, err := access.CanListAndWatch("", "secrets", metaV1.NamespaceAll)
+		if err != nil {
+			return nil, err
+		}
+
+		factories.Namespaced = append(factories.Namespaced, NamespacedFactory{namespaced, cluster, metaV1.NamespaceAll, secretsAllowed, autoscalersServed})
+		return factories, nil
+	}
+
+	factories.System = informers.NewSharedInformerFactoryWithOptions(client, resync, informers.WithNamespace(SystemNamespace))
+
+	for _, namespace := range namespaces {
+		unfiltered := informers.NewSharedInformerFactoryWithOptions(client, resync, informers.WithNamespace(namespace))
+		factory := unfiltered
+		if selector != "" {
+			factory = informers.NewSharedInformerFactoryWithOptions(client, resync, informers.WithNamespace(namespace), withLabelSelector(selector))
+		}
+
+		secretsAllowed, err := access.CanListAndWatch("", "secrets", namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		factories.Namespaced = append(factories.Namespaced, NamespacedFactory{factory, unfiltered, namespace, secretsAllowed, autoscalersServed})
+	}
+
+	return factories, nil
+}
+
+// IsRestricted returns true if the factories only observe a subset of the namespaces in the cluster
+func (f *Factories) IsRestricted() bool {
+	return len(f.Namespaced) != 1 || f.Namespaced[0].Namespace != metaV1.NamespaceAll
+}
+
+// Start starts all the informers that have been requested from the factories
+func (f *Factories) Start(stop <-chan struct{}) {
+	f.Cluster.Start(stop)
+	f.System.Start(stop)
+	for _, factory := range f.Namespaced {
+		factory.Start(stop)
+		factory.Unfiltered.Start(stop)
+	}
+}
+
+// WaitForCacheSync waits for the caches of all the started informers to be synced
+func (f *Factories) WaitForCacheSync(stop <-chan struct{}) {
+	f.Cluster.WaitForCacheSync(stop)
+	f.System.WaitForCacheSync(stop)
+	for _, factory := range f.Namespaced {
+		factory.WaitForCacheSync(stop)
+		factory.Unfiltered.WaitForCacheSync(stop)
+	}
+}
+
+func withLabelSelector(selector string) informers.SharedInformerOption {
+	return informers.WithTweakListOptions(func(options *metaV1.ListOptions) {
+		options.LabelSelector = selector
+	})
+}
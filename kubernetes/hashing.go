@@ -6,8 +6,10 @@
 package kubernetes
 
 import (
+	"crypto/hmac"
 	"crypto/sha512"
 	"fmt"
+	"github.com/knadh/koanf"
 	"hash"
 	coreV1 "k8s.io/api/core/v1"
 	"sort"
@@ -48,7 +50,10 @@ func (h ConfigHasher) WriteConfigMap(configMap *coreV1.ConfigMap) {
 	}
 }
 
-func (h ConfigHasher) WriteSecret(secret *coreV1.Secret) {
+// WriteSecret writes the keys and the digests of the values of a Secret.
+// The values of a Secret that was redacted by a Recorder are already digests, so they are written as they are,
+// and the computed hash is the same as the one computed from the live Secret with the same SecretDigester.
+func (h ConfigHasher) WriteSecret(secret *coreV1.Secret, digester SecretDigester) {
 	if len(secret.Data) > 0 {
 		keys := make([]string, 0, len(secret.Data))
 		for key := range secret.Data {
@@ -57,7 +62,11 @@ func (h ConfigHasher) WriteSecret(secret *coreV1.Secret) {
 		sort.Strings(keys)
 		for _, key := range keys {
 			h.hasher.Write([]byte(key))
-			h.hasher.Write([]byte(secret.Data[key]))
+			if IsRedactedSecret(secret) {
+				h.hasher.Write(secret.Data[key])
+			} else {
+				h.hasher.Write(digester.Digest(secret.Data[key]))
+			}
 		}
 	}
 }
@@ -66,3 +75,71 @@ func (h ConfigHasher) GetComputedHash() string {
 	hash := h.hasher.Sum(nil)
 	return fmt.Sprintf("%x", hash)
 }
+
+// SecretDigester computes the digests of Secret values that are written to configuration hashes and recordings.
+// The digests are HMACs, so that the values of Secrets with little entropy cannot be guessed from a recording without the key.
+type SecretDigester struct {
+	key []byte
+}
+
+func NewSecretDigester(key []byte) SecretDigester {
+	return SecretDigester{
+		key: key,
+	}
+}
+
+// NewSecretDigesterUsing creates a SecretDigester with the key from --secrets.key
+func NewSecretDigesterUsing(config *koanf.Koanf) SecretDigester {
+	return NewSecretDigester([]byte(config.String("secrets.key")))
+}
+
+// Digest returns the HMAC of the value
+func (d SecretDigester) Digest(value []byte) []byte {
+	mac := hmac.New(sha512.New, d.key)
+	mac.Write(value)
+	return mac.Sum(nil)
+}
+
+const (
+	// RedactedSecretAnnotation marks a Secret where the values have been replaced
+	RedactedSecretAnnotation = "fleet-observer.dolittle.io/redacted"
+	// redactedWithDigests is the value of the RedactedSecretAnnotation when the values have been replaced by the digests of a SecretDigester.
+	// Recordings made by earlier versions contain unkeyed hashes of the values instead, and are annotated with "true".
+	redactedWithDigests = "digests"
+)
+
+// RedactSecret returns a copy of the Secret where the values are replaced by their digests, so that WriteSecret computes the same hash from the copy.
+// The last applied configuration annotation is removed, since it can contain the values.
+func RedactSecret(secret *coreV1.Secret, digester SecretDigester) *coreV1.Secret {
+	redacted := secret.DeepCopy()
+	if IsRedactedSecret(secret) {
+		return redacted
+	}
+
+	for key, value := range secret.Data {
+		redacted.Data[key] = digester.Digest(value)
+	}
+	redacted.StringData = nil
+
+	annotations := map[string]string{}
+	for key, value := range secret.GetAnnotations() {
+		if key != coreV1.LastAppliedConfigAnnotation {
+			annotations[key] = value
+		}
+	}
+	annotations[RedactedSecretAnnotation] = redactedWithDigests
+	redacted.SetAnnotations(annotations)
+	return redacted
+}
+
+// IsRedactedSecret returns true if the values of the Secret have been replaced
+func IsRedactedSecret(secret *coreV1.Secret) bool {
+	_, ok := secret.GetAnnotations()[RedactedSecretAnnotation]
+	return ok
+}
+
+// HasSecretDigests returns true if the values of a redacted Secret are digests, so that the hash computed by WriteSecret is the same as the live one.
+// This is false for Secrets redacted by earlier versions, since they contain unkeyed hashes of the values.
+func HasSecretDigests(secret *coreV1.Secret) bool {
+	return secret.GetAnnotations()[RedactedSecretAnnotation] == redactedWithDigests
+}
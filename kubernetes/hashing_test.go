@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package kubernetes
+
+import (
+	"bytes"
+	"github.com/rs/zerolog"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func newEnvironmentVariables() (*coreV1.ConfigMap, *coreV1.Secret) {
+	configMap := &coreV1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "microservice-env-variables", Namespace: "application"},
+		Data:       map[string]string{"LOG_LEVEL": "debug"},
+	}
+	secret := &coreV1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "microservice-secret-env-variables", Namespace: "application"},
+		Data: map[string][]byte{
+			"PASSWORD": []byte("hunter2"),
+			"TOKEN":    []byte("1234"),
+		},
+	}
+	return configMap, secret
+}
+
+// customerConfigHash computes the hash of the environment variables in the same way as the pods observer
+func customerConfigHash(configMap *coreV1.ConfigMap, secret *coreV1.Secret, digester SecretDigester) string {
+	hasher := NewConfigHasher()
+	hasher.WriteConfigMap(configMap)
+	hasher.WriteSecret(secret, digester)
+	return hasher.GetComputedHash()
+}
+
+// recordAndLoadSecret writes the Secret to a recording, and returns the Secret that is loaded when the recording is replayed
+func recordAndLoadSecret(t *testing.T, secret *coreV1.Secret, digester SecretDigester) *coreV1.Secret {
+	t.Helper()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, 1024*1024, 1, digester, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.write(secret, false)
+	recorder.Close()
+
+	objects, err := LoadObjectsFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range objects {
+		if replayed, ok := object.(*coreV1.Secret); ok {
+			return replayed
+		}
+	}
+	t.Fatal("the recording does not contain the Secret")
+	return nil
+}
+
+func TestReplayedConfigurationHashIsTheSameAsLive(t *testing.T) {
+	digester := NewSecretDigester([]byte("key"))
+	configMap, secret := newEnvironmentVariables()
+
+	replayed := recordAndLoadSecret(t, secret, digester)
+
+	if !IsRedactedSecret(replayed) || !HasSecretDigests(replayed) {
+		t.Fatalf("expected the replayed Secret to be redacted with digests, got annotations %v", replayed.GetAnnotations())
+	}
+	live := customerConfigHash(configMap, secret, digester)
+	if hash := customerConfigHash(configMap, replayed, NewSecretDigester([]byte("other key"))); hash != live {
+		t.Errorf("expected the replayed hash to be the live hash %v, got %v", live, hash)
+	}
+}
+
+func TestRecordedSecretValuesDependOnTheKey(t *testing.T) {
+	_, secret := newEnvironmentVariables()
+
+	first := RedactSecret(secret, NewSecretDigester([]byte("first")))
+	second := RedactSecret(secret, NewSecretDigester([]byte("second")))
+
+	for key, value := range secret.Data {
+		if bytes.Contains(first.Data[key], value) {
+			t.Errorf("expected the recorded value of %v not to contain the value", key)
+		}
+		if bytes.Equal(first.Data[key], second.Data[key]) {
+			t.Errorf("expected the recorded value of %v to depend on the key", key)
+		}
+	}
+}
+
+func TestSecretsRedactedByEarlierVersionsAreNotDigests(t *testing.T) {
+	_, secret := newEnvironmentVariables()
+	secret.SetAnnotations(map[string]string{RedactedSecretAnnotation: "true"})
+
+	if !IsRedactedSecret(secret) {
+		t.Error("expected the Secret to be redacted")
+	}
+	if HasSecretDigests(secret) {
+		t.Error("expected the Secret not to have digests")
+	}
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package kubernetes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"k8s.io/client-go/rest"
+	"os"
+	"strings"
+)
+
+// getIdentity guesses the user that the config authenticates as, without contacting the API server.
+// For bearer tokens that are JWTs (like ServiceAccount tokens), the subject of the token is used without verifying the token.
+func getIdentity(config *rest.Config) string {
+	if config.Username != "" {
+		return config.Username
+	}
+
+	token := config.BearerToken
+	if token == "" && config.BearerTokenFile != "" {
+		if data, err := os.ReadFile(config.BearerTokenFile); err == nil {
+			token = strings.TrimSpace(string(data))
+		}
+	}
+
+	return getTokenSubject(token)
+}
+
+func getTokenSubject(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Subject
+}
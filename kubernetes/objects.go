@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadObjectsFromDir reads all the Kubernetes objects from the YAML and JSON files in a directory and its subdirectories.
+// Files can contain multiple YAML documents, and List objects are expanded into their items.
+// If an object occurs multiple times, like in the segments written by a Recorder, the last occurrence in file name order is used.
+func LoadObjectsFromDir(dir string) ([]runtime.Object, error) {
+	var objects []runtime.Object
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		loaded, err := loadObjectsFromFile(path)
+		if err != nil {
+			return fmt.Errorf("could not load objects from %v: %w", path, err)
+		}
+		objects = append(objects, loaded...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return latestObjects(objects)
+}
+
+// latestObjects removes all but the last occurrence of each object, and the objects that were recorded as deleted
+func latestObjects(objects []runtime.Object) ([]runtime.Object, error) {
+	var keys []string
+	latest := map[string]runtime.Object{}
+	for _, object := range objects {
+		accessor, err := meta.Accessor(object)
+		if err != nil {
+			return nil, err
+		}
+
+		key := fmt.Sprintf("%v/%v/%v", object.GetObjectKind().GroupVersionKind(), accessor.GetNamespace(), accessor.GetName())
+		if _, ok := latest[key]; !ok {
+			keys = append(keys, key)
+		}
+		latest[key] = object
+	}
+
+	var result []runtime.Object
+	for _, key := range keys {
+		object := latest[key]
+		if accessor, _ := meta.Accessor(object); accessor.GetAnnotations()[DeletedObjectAnnotation] == "true" {
+			continue
+		}
+		result = append(result, object)
+	}
+	return result, nil
+}
+
+func loadObjectsFromFile(path string) ([]runtime.Object, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var objects []runtime.Object
+	decoder := yaml.NewYAMLOrJSONDecoder(file, 4096)
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+			return objects, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(raw.Raw) == 0 || string(raw.Raw) == "null" {
+			continue
+		}
+
+		decoded, err := decodeObject(raw.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+}
+
+func decodeObject(data []byte) ([]runtime.Object, error) {
+	object, _, err := scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := object.(*coreV1.List)
+	if !ok {
+		return []runtime.Object{object}, nil
+	}
+
+	var objects []runtime.Object
+	for _, item := range list.Items {
+		decoded, err := decodeObject(item.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
@@ -10,12 +10,19 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"sync/atomic"
+	"time"
 )
 
 type Observer struct {
-	queue  workqueue.RateLimitingInterface
-	index  cache.Indexer
-	logger zerolog.Logger
+	name       string
+	queue      workqueue.RateLimitingInterface
+	index      cache.Indexer
+	processing int32
+	failing    int32
+	failed     int32
+	maxRetries int32
+	logger     zerolog.Logger
 }
 
 func NewObserver(name string, informer cache.SharedIndexInformer, logger zerolog.Logger) *Observer {
@@ -41,6 +48,7 @@ func NewObserver(name string, informer cache.SharedIndexInformer, logger zerolog
 	index := informer.GetIndexer()
 
 	return &Observer{
+		name:   name,
 		queue:  queue,
 		index:  index,
 		logger: logger,
@@ -59,6 +67,7 @@ func (o *Observer) handleQueue(handler ObserverHandler) {
 		if shutdown {
 			break
 		}
+		atomic.AddInt32(&o.processing, 1)
 
 		meta, ok := item.(metaV1.Object)
 		if !ok {
@@ -78,22 +87,72 @@ func (o *Observer) handleQueue(handler ObserverHandler) {
 
 		_, exists, err := o.index.GetByKey(key)
 		if err != nil {
-			o.queue.AddRateLimited(item)
 			logger.Warn().Err(err).Msg("Failed to get item from index")
+			o.retry(item, logger)
 		} else if err := handler.Handle(item, !exists); err != nil {
-			o.queue.AddRateLimited(item)
 			logger.Warn().Err(err).Msg("Error occurred while handling item")
+			o.retry(item, logger)
 		} else {
-			o.queue.Forget(item)
+			o.forget(item)
 			logger.Debug().Msg("Done handling item")
 		}
 
 		o.queue.Done(item)
+		atomic.AddInt32(&o.processing, -1)
 	}
 
 	o.logger.Debug().Msg("Queue has been shut down")
 }
 
+// retry adds the item back to the queue after the rate limited delay, or gives up if it has been retried the maximum number of times
+func (o *Observer) retry(item any, logger zerolog.Logger) {
+	requeues := o.queue.NumRequeues(item)
+	if maxRetries := int(atomic.LoadInt32(&o.maxRetries)); maxRetries > 0 && requeues >= maxRetries {
+		logger.Error().Int("retries", requeues).Msg("Giving up handling item")
+		o.forget(item)
+		atomic.AddInt32(&o.failed, 1)
+		return
+	}
+
+	if requeues == 0 {
+		atomic.AddInt32(&o.failing, 1)
+	}
+	o.queue.AddRateLimited(item)
+}
+
+func (o *Observer) forget(item any) {
+	if o.queue.NumRequeues(item) > 0 {
+		atomic.AddInt32(&o.failing, -1)
+	}
+	o.queue.Forget(item)
+}
+
+// Name returns the name of the observer
+func (o *Observer) Name() string {
+	return o.name
+}
+
+// StopRetryingAfter makes the observer give up handling items that have failed to be handled after the number of retries,
+// so that an observer that only runs until it is idle is not kept busy retrying items that will never be handled
+func (o *Observer) StopRetryingAfter(retries int) {
+	atomic.StoreInt32(&o.maxRetries, int32(retries))
+}
+
+// IsIdle returns true if there are no items waiting in the queue, being handled, or waiting to be retried after a failure
+func (o *Observer) IsIdle() bool {
+	return o.queue.Len() == 0 && atomic.LoadInt32(&o.processing) == 0 && atomic.LoadInt32(&o.failing) == 0
+}
+
+// Failing returns the number of items that failed to be handled and are waiting to be retried
+func (o *Observer) Failing() int {
+	return int(atomic.LoadInt32(&o.failing))
+}
+
+// Failed returns the number of items that the observer gave up handling, see StopRetryingAfter
+func (o *Observer) Failed() int {
+	return int(atomic.LoadInt32(&o.failed))
+}
+
 func (o *Observer) shutdownWhenStopped(stopCh <-chan struct{}) {
 	<-stopCh
 	o.logger.Debug().Msg("Stopping queue")
@@ -114,3 +173,28 @@ func (fs ObserverHandlerFuncs) Handle(obj any, deleted bool) error {
 	}
 	return nil
 }
+
+// WaitUntilIdle waits until all the observers have been idle for the provided duration, or until stopped
+func WaitUntilIdle(observers []*Observer, idle time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(idle / 10)
+	defer ticker.Stop()
+
+	idleSince := time.Now()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			for _, observer := range observers {
+				if !observer.IsIdle() {
+					idleSince = now
+					break
+				}
+			}
+
+			if now.Sub(idleSince) >= idle {
+				return
+			}
+		}
+	}
+}
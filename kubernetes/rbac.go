@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package kubernetes
+
+import (
+	"fmt"
+	coreV1 "k8s.io/api/core/v1"
+	rbacV1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	"sort"
+)
+
+// ObserverVerbs are the verbs that are needed to run an informer for a resource
+var ObserverVerbs = []string{"get", "list", "watch"}
+
+// Permission is the access to a resource that is needed by the observer, in a namespace or cluster-wide if the namespace is empty
+type Permission struct {
+	Group     string
+	Resource  string
+	Namespace string
+	Verbs     []string
+}
+
+// GetRequiredPermissions returns the permissions needed to run the informers that have been requested from the factories.
+// The factories are started and synced to find the informers, so they should be created with a fake client.
+func (f *Factories) GetRequiredPermissions(stop <-chan struct{}) ([]Permission, error) {
+	var permissions []Permission
+
+	f.Cluster.Start(stop)
+	clusterPermissions, err := getStartedInformerPermissions(f.Cluster, metaV1.NamespaceAll, stop)
+	if err != nil {
+		return nil, err
+	}
+	permissions = append(permissions, clusterPermissions...)
+
+	if f.System != f.Cluster {
+		f.System.Start(stop)
+		systemPermissions, err := getStartedInformerPermissions(f.System, SystemNamespace, stop)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, systemPermissions...)
+	}
+
+	for _, factory := range f.Namespaced {
+		for _, namespaced := range []informers.SharedInformerFactory{factory.SharedInformerFactory, factory.Unfiltered} {
+			if namespaced == f.Cluster {
+				continue
+			}
+
+			namespaced.Start(stop)
+			namespacedPermissions, err := getStartedInformerPermissions(namespaced, factory.Namespace, stop)
+			if err != nil {
+				return nil, err
+			}
+			permissions = append(permissions, namespacedPermissions...)
+		}
+	}
+
+	return mergePermissions(permissions), nil
+}
+
+func getStartedInformerPermissions(factory informers.SharedInformerFactory, namespace string, stop <-chan struct{}) ([]Permission, error) {
+	var permissions []Permission
+	for informerType := range factory.WaitForCacheSync(stop) {
+		object, ok := reflect.New(informerType.Elem()).Interface().(runtime.Object)
+		if !ok {
+			return nil, fmt.Errorf("informer type %v is not a Kubernetes object", informerType)
+		}
+
+		kinds, _, err := scheme.Scheme.ObjectKinds(object)
+		if err != nil {
+			return nil, err
+		}
+
+		resource, _ := meta.UnsafeGuessKindToResource(kinds[0])
+		permissions = append(permissions, Permission{
+			Group:     resource.Group,
+			Resource:  resource.Resource,
+			Namespace: namespace,
+			Verbs:     ObserverVerbs,
+		})
+	}
+	return permissions, nil
+}
+
+// mergePermissions removes duplicate permissions, and sorts them by namespace, group and resource
+func mergePermissions(permissions []Permission) []Permission {
+	seen := map[string]bool{}
+	var merged []Permission
+	for _, permission := range permissions {
+		key := fmt.Sprintf("%v/%v/%v", permission.Namespace, permission.Group, permission.Resource)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, permission)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Namespace != merged[j].Namespace {
+			return merged[i].Namespace < merged[j].Namespace
+		}
+		if merged[i].Group != merged[j].Group {
+			return merged[i].Group < merged[j].Group
+		}
+		return merged[i].Resource < merged[j].Resource
+	})
+	return merged
+}
+
+// NewRBACManifests creates a ServiceAccount, and the ClusterRole, Roles and bindings that grant it the permissions.
+// Cluster-wide permissions are granted using a ClusterRole, and namespaced permissions using a Role in each namespace.
+func NewRBACManifests(name, serviceAccountNamespace string, permissions []Permission) []runtime.Object {
+	serviceAccount := &coreV1.ServiceAccount{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: serviceAccountNamespace},
+	}
+	subjects := []rbacV1.Subject{{Kind: rbacV1.ServiceAccountKind, Name: name, Namespace: serviceAccountNamespace}}
+
+	var namespaces []string
+	rulesByNamespace := map[string][]rbacV1.PolicyRule{}
+	for _, permission := range permissions {
+		if _, ok := rulesByNamespace[permission.Namespace]; !ok {
+			namespaces = append(namespaces, permission.Namespace)
+		}
+		rulesByNamespace[permission.Namespace] = append(rulesByNamespace[permission.Namespace], rbacV1.PolicyRule{
+			APIGroups: []string{permission.Group},
+			Resources: []string{permission.Resource},
+			Verbs:     permission.Verbs,
+		})
+	}
+
+	manifests := []runtime.Object{serviceAccount}
+	for _, namespace := range namespaces {
+		if namespace == metaV1.NamespaceAll {
+			manifests = append(manifests,
+				&rbacV1.ClusterRole{
+					TypeMeta:   metaV1.TypeMeta{APIVersion: rbacV1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+					ObjectMeta: metaV1.ObjectMeta{Name: name},
+					Rules:      rulesByNamespace[namespace],
+				},
+				&rbacV1.ClusterRoleBinding{
+					TypeMeta:   metaV1.TypeMeta{APIVersion: rbacV1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+					ObjectMeta: metaV1.ObjectMeta{Name: name},
+					Subjects:   subjects,
+					RoleRef:    rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "ClusterRole", Name: name},
+				},
+			)
+			continue
+		}
+
+		manifests = append(manifests,
+			&rbacV1.Role{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: rbacV1.SchemeGroupVersion.String(), Kind: "Role"},
+				ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace},
+				Rules:      rulesByNamespace[namespace],
+			},
+			&rbacV1.RoleBinding{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: rbacV1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+				ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace},
+				Subjects:   subjects,
+				RoleRef:    rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "Role", Name: name},
+			},
+		)
+	}
+	return manifests
+}
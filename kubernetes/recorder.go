@@ -0,0 +1,225 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package kubernetes
+
+import (
+	"fmt"
+	"github.com/rs/zerolog"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DeletedObjectAnnotation marks a recorded object that was deleted from the cluster
+const DeletedObjectAnnotation = "fleet-observer.dolittle.io/deleted"
+
+const recordingSegmentTimeFormat = "20060102T150405.000000000Z"
+
+// Recorder writes the objects that informers see to a rotating archive of YAML segments that can be replayed using LoadObjectsFromDir.
+// Each segment starts with a snapshot of all the objects in the informers, so old segments can be removed without losing the current state.
+type Recorder struct {
+	dir         string
+	segmentSize int64
+	maxSegments int
+	stores      []cache.Store
+	lock        sync.Mutex
+	segment     *os.File
+	written     int64
+	snapshot    int64
+	digester    SecretDigester
+	logger      zerolog.Logger
+}
+
+func NewRecorder(dir string, segmentSize int64, maxSegments int, digester SecretDigester, logger zerolog.Logger) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		dir:         dir,
+		segmentSize: segmentSize,
+		maxSegments: maxSegments,
+		digester:    digester,
+		logger:      logger.With().Str("recorder", dir).Logger(),
+	}, nil
+}
+
+// RecordAll records the objects of all the informers that are used by the observers
+func (r *Recorder) RecordAll(factories *Factories) {
+	r.Record(factories.Cluster.Core().V1().Nodes().Informer())
+	r.Record(factories.Cluster.Core().V1().Namespaces().Informer())
+	r.Record(factories.System.Core().V1().ConfigMaps().Informer())
+
+	for _, factory := range factories.Namespaced {
+		r.Record(factory.Unfiltered.Core().V1().ConfigMaps().Informer())
+		if factory.SecretsAllowed {
+			r.Record(factory.Unfiltered.Core().V1().Secrets().Informer())
+		}
+		r.Record(factory.Core().V1().Pods().Informer())
+		r.Record(factory.Unfiltered.Core().V1().Events().Informer())
+		r.Record(factory.Core().V1().Services().Informer())
+		r.Record(factory.Unfiltered.Apps().V1().Deployments().Informer())
+		r.Record(factory.Apps().V1().ReplicaSets().Informer())
+		r.Record(factory.Batch().V1().Jobs().Informer())
+		r.Record(factory.Unfiltered.Batch().V1().CronJobs().Informer())
+		r.Record(factory.Networking().V1().Ingresses().Informer())
+		if factory.AutoscalersServed {
+			r.Record(factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer())
+		}
+	}
+}
+
+// Record writes the objects that are added, updated or deleted in the informer
+func (r *Recorder) Record(informer cache.SharedIndexInformer) {
+	r.lock.Lock()
+	r.stores = append(r.stores, informer.GetStore())
+	r.lock.Unlock()
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			r.write(obj, false)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldMeta, oldErr := meta.Accessor(oldObj)
+			newMeta, newErr := meta.Accessor(newObj)
+			if oldErr == nil && newErr == nil && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+				return
+			}
+			r.write(newObj, false)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			r.write(obj, true)
+		},
+	})
+}
+
+// Close closes the current segment
+func (r *Recorder) Close() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.segment != nil {
+		r.segment.Close()
+		r.segment = nil
+	}
+}
+
+func (r *Recorder) write(obj any, deleted bool) {
+	object, ok := obj.(runtime.Object)
+	if !ok {
+		r.logger.Warn().Msgf("Skipping recording of %T because it is not a Kubernetes object", obj)
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// the snapshot at the start of the segment is not counted, so that large clusters do not rotate on every change
+	if r.segment == nil || r.written-r.snapshot >= r.segmentSize {
+		if err := r.rotate(); err != nil {
+			r.logger.Error().Err(err).Msg("Failed to rotate recording segment")
+			return
+		}
+	}
+
+	if err := r.writeObject(object, deleted); err != nil {
+		r.logger.Error().Err(err).Msg("Failed to record object")
+	}
+}
+
+func (r *Recorder) rotate() error {
+	if r.segment != nil {
+		if err := r.segment.Close(); err != nil {
+			return err
+		}
+	}
+
+	name := fmt.Sprintf("segment-%v.yaml", time.Now().UTC().Format(recordingSegmentTimeFormat))
+	segment, err := os.Create(filepath.Join(r.dir, name))
+	if err != nil {
+		return err
+	}
+	r.segment = segment
+	r.written = 0
+	r.logger.Debug().Str("segment", name).Msg("Started recording segment")
+
+	for _, store := range r.stores {
+		for _, obj := range store.List() {
+			if object, ok := obj.(runtime.Object); ok {
+				if err := r.writeObject(object, false); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	r.snapshot = r.written
+
+	return r.removeOldSegments()
+}
+
+func (r *Recorder) removeOldSegments() error {
+	segments, err := filepath.Glob(filepath.Join(r.dir, "segment-*.yaml"))
+	if err != nil || len(segments) <= r.maxSegments {
+		return err
+	}
+
+	sort.Strings(segments)
+	for _, segment := range segments[:len(segments)-r.maxSegments] {
+		if err := os.Remove(segment); err != nil {
+			return err
+		}
+		r.logger.Debug().Str("segment", filepath.Base(segment)).Msg("Removed old recording segment")
+	}
+	return nil
+}
+
+func (r *Recorder) writeObject(object runtime.Object, deleted bool) error {
+	kinds, _, err := scheme.Scheme.ObjectKinds(object)
+	if err != nil {
+		return err
+	}
+
+	object = object.DeepCopyObject()
+	if secret, ok := object.(*coreV1.Secret); ok {
+		object = RedactSecret(secret, r.digester)
+	}
+	object.GetObjectKind().SetGroupVersionKind(kinds[0])
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return err
+	}
+	accessor.SetManagedFields(nil)
+	if deleted {
+		annotations := accessor.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[DeletedObjectAnnotation] = "true"
+		accessor.SetAnnotations(annotations)
+	}
+
+	data, err := yaml.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	written, err := fmt.Fprintf(r.segment, "---\n%s", data)
+	r.written += int64(written)
+	return err
+}
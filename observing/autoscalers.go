@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+	"github.com/rs/zerolog"
+	appsV1 "k8s.io/api/apps/v1"
+	autoscalingV2 "k8s.io/api/autoscaling/v2"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	listersAppsV1 "k8s.io/client-go/listers/apps/v1"
+	"time"
+)
+
+// scaledEventTolerance is how far apart the times of the same rescale can be when they are read from the status of an autoscaler and from its event
+const scaledEventTolerance = 2 * time.Second
+
+type AutoscalersHandler struct {
+	autoscalers storage.Autoscalers
+	deployments listersAppsV1.DeploymentLister
+	replicasets listersAppsV1.ReplicaSetLister
+	logger      zerolog.Logger
+}
+
+func NewAutoscalersHandler(autoscalers storage.Autoscalers, deployments listersAppsV1.DeploymentLister, replicasets listersAppsV1.ReplicaSetLister, logger zerolog.Logger) *AutoscalersHandler {
+	return &AutoscalersHandler{
+		autoscalers: autoscalers,
+		deployments: deployments,
+		replicasets: replicasets,
+		logger:      logger.With().Str("handler", "autoscalers").Logger(),
+	}
+}
+
+func (ah *AutoscalersHandler) Handle(obj any, _deleted bool) error {
+	hpa, ok := obj.(*autoscalingV2.HorizontalPodAutoscaler)
+	if !ok {
+		return ReceivedWrongType(obj, "HorizontalPodAutoscaler")
+	}
+
+	logger := ah.logger.With().Str("namespace", hpa.GetNamespace()).Str("name", hpa.GetName()).Logger()
+
+	deploymentUID, ok, err := GetScaledDeploymentUID(hpa, ah.deployments)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		logger.Trace().Msg("Skipping autoscaler because it does not scale a deployment with microservice identifiers")
+		return nil
+	}
+
+	minReplicas := 1
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = int(*hpa.Spec.MinReplicas)
+	}
+
+	var lastScaled *time.Time
+	if hpa.Status.LastScaleTime != nil {
+		scaled := hpa.Status.LastScaleTime.UTC()
+		lastScaled = &scaled
+	}
+
+	autoscaler := entities.NewAutoscaler(
+		deploymentUID,
+		hpa.GetName(),
+		minReplicas,
+		int(hpa.Spec.MaxReplicas),
+		getTargetUtilization(hpa, coreV1.ResourceCPU),
+		getTargetUtilization(hpa, coreV1.ResourceMemory),
+		int(hpa.Status.CurrentReplicas),
+		lastScaled,
+	)
+
+	// the stored autoscaler is the status that was observed before this one, so it is used to find out if and from what the deployment has been scaled since
+	stored, exists, err := ah.autoscalers.Get(autoscaler.UID)
+	if err != nil {
+		return err
+	}
+
+	if err := ah.autoscalers.Set(autoscaler); err != nil {
+		return err
+	}
+	logger.Debug().Interface("autoscaler", autoscaler).Msg("Updated autoscaler")
+
+	if lastScaled == nil {
+		return nil
+	}
+	if exists && stored.Properties.LastScaled != nil && !lastScaled.After(*stored.Properties.LastScaled) {
+		logger.Trace().Msg("Skipping scaled event because it was recorded when the autoscaler was observed before")
+		return nil
+	}
+
+	scaledDeploymentUID, ok, err := GetScaledDeploymentUIDAt(hpa, *lastScaled, ah.deployments, ah.replicasets)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		logger.Trace().Msg("Skipping scaled event because the revision of the deployment at the time it was scaled is not known")
+		return nil
+	}
+
+	// the number of replicas before the rescale is only known if the autoscaler was observed before it, and autoscalers stored by earlier versions do not have it
+	var from *int
+	if exists && stored.Properties.Replicas > 0 {
+		from = &stored.Properties.Replicas
+	}
+
+	// the desired replicas are the size of the last rescale until the autoscaler scales again, which also moves the last scale time
+	event := entities.NewScaledEvent(
+		scaledDeploymentUID,
+		*lastScaled,
+		from,
+		int(hpa.Status.DesiredReplicas),
+		"",
+	)
+	if err := SetScaledEvent(ah.autoscalers, event); err != nil {
+		return err
+	}
+	logger.Debug().Interface("event", event).Msg("Updated scaled event")
+
+	return nil
+}
+
+// GetScaledDeploymentUID finds the Deployment entity of the current revision of the deployment that is scaled by an autoscaler
+func GetScaledDeploymentUID(hpa *autoscalingV2.HorizontalPodAutoscaler, deployments listersAppsV1.DeploymentLister) (entities.DeploymentUID, bool, error) {
+	deployment, ok, err := getScaledDeployment(hpa, deployments)
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	tenantID, applicationID, environmentName, _, ok := GetMicroserviceIdentifiers(deployment.ObjectMeta)
+	if !ok {
+		return "", false, nil
+	}
+
+	revision, ok := deployment.GetAnnotations()["deployment.kubernetes.io/revision"]
+	if !ok {
+		return "", false, nil
+	}
+
+	return entities.NewDeploymentUID(tenantID, applicationID, environmentName, revision), true, nil
+}
+
+// GetScaledDeploymentUIDAt finds the Deployment entity of the revision of the deployment that is scaled by an autoscaler that was current at the provided time,
+// which is the revision of the newest ReplicaSet of the deployment that was created before then. If there is no such ReplicaSet, the current revision is used.
+func GetScaledDeploymentUIDAt(hpa *autoscalingV2.HorizontalPodAutoscaler, at time.Time, deployments listersAppsV1.DeploymentLister, replicasets listersAppsV1.ReplicaSetLister) (entities.DeploymentUID, bool, error) {
+	deployment, ok, err := getScaledDeployment(hpa, deployments)
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	tenantID, applicationID, environmentName, _, ok := GetMicroserviceIdentifiers(deployment.ObjectMeta)
+	if !ok {
+		return "", false, nil
+	}
+
+	owned, err := replicasets.ReplicaSets(deployment.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return "", false, err
+	}
+
+	revision, hasRevision := deployment.GetAnnotations()["deployment.kubernetes.io/revision"]
+	var revisionCreated time.Time
+	foundReplicaSet := false
+	for _, replicaset := range owned {
+		replicasetRevision, ok := replicaset.GetAnnotations()["deployment.kubernetes.io/revision"]
+		created := replicaset.GetCreationTimestamp().Time
+		if !ok || !metaV1.IsControlledBy(replicaset, deployment) || created.After(at) {
+			continue
+		}
+		if !foundReplicaSet || created.After(revisionCreated) {
+			revision, revisionCreated, hasRevision, foundReplicaSet = replicasetRevision, created, true, true
+		}
+	}
+	if !hasRevision {
+		return "", false, nil
+	}
+
+	return entities.NewDeploymentUID(tenantID, applicationID, environmentName, revision), true, nil
+}
+
+func getScaledDeployment(hpa *autoscalingV2.HorizontalPodAutoscaler, deployments listersAppsV1.DeploymentLister) (*appsV1.Deployment, bool, error) {
+	if hpa.Spec.ScaleTargetRef.Kind != "Deployment" {
+		return nil, false, nil
+	}
+
+	deployment, err := deployments.Deployments(hpa.GetNamespace()).Get(hpa.Spec.ScaleTargetRef.Name)
+	if errors.IsNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return deployment, true, nil
+}
+
+// SetScaledEvent stores a scaled event, merging it with the information already recorded from another source for the same rescale.
+// The times read from the different sources can differ slightly, so the event is merged into a stored event of the same deployment
+// that scaled to the same number of replicas within scaledEventTolerance, and keeps the UID and time of the stored event.
+func SetScaledEvent(autoscalers storage.Autoscalers, event entities.ScaledEvent) error {
+	existing, exists, err := findScaledEvent(autoscalers, event)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		event.UID = existing.UID
+		event.Properties.Time = existing.Properties.Time
+		if event.Properties.From == nil {
+			event.Properties.From = existing.Properties.From
+		}
+		if event.Properties.Reason == "" {
+			event.Properties.Reason = existing.Properties.Reason
+		}
+	}
+
+	return autoscalers.SetEvent(event)
+}
+
+func findScaledEvent(autoscalers storage.Autoscalers, event entities.ScaledEvent) (*entities.ScaledEvent, bool, error) {
+	candidates := []time.Time{event.Properties.Time}
+	for offset := time.Second; offset <= scaledEventTolerance; offset += time.Second {
+		candidates = append(candidates, event.Properties.Time.Add(-offset), event.Properties.Time.Add(offset))
+	}
+
+	for _, scaled := range candidates {
+		existing, exists, err := autoscalers.GetEvent(entities.NewScaledEventUID(event.Links.HappenedToDeploymentUID, scaled))
+		if err != nil {
+			return nil, false, err
+		}
+		if exists && existing.Properties.To == event.Properties.To {
+			return existing, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func getTargetUtilization(hpa *autoscalingV2.HorizontalPodAutoscaler, resource coreV1.ResourceName) *int {
+	for _, metric := range hpa.Spec.Metrics {
+		if metric.Type != autoscalingV2.ResourceMetricSourceType || metric.Resource == nil || metric.Resource.Name != resource {
+			continue
+		}
+		if metric.Resource.Target.AverageUtilization == nil {
+			continue
+		}
+
+		utilization := int(*metric.Resource.Target.AverageUtilization)
+		return &utilization
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+	"github.com/rs/zerolog"
+	appsV1 "k8s.io/api/apps/v1"
+	autoscalingV2 "k8s.io/api/autoscaling/v2"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listersAppsV1 "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"testing"
+	"time"
+)
+
+// storedAutoscalers is an Autoscalers repository that keeps the written autoscalers and scaled events in memory
+type storedAutoscalers struct {
+	storage.Autoscalers
+	autoscalers map[entities.AutoscalerUID]entities.Autoscaler
+	events      map[entities.ScaledEventUID]entities.ScaledEvent
+}
+
+func (s *storedAutoscalers) Set(autoscaler entities.Autoscaler) error {
+	s.autoscalers[autoscaler.UID] = autoscaler
+	return nil
+}
+
+func (s *storedAutoscalers) Get(id entities.AutoscalerUID) (*entities.Autoscaler, bool, error) {
+	autoscaler, ok := s.autoscalers[id]
+	return &autoscaler, ok, nil
+}
+
+func (s *storedAutoscalers) SetEvent(event entities.ScaledEvent) error {
+	s.events[event.UID] = event
+	return nil
+}
+
+func (s *storedAutoscalers) GetEvent(id entities.ScaledEventUID) (*entities.ScaledEvent, bool, error) {
+	event, ok := s.events[id]
+	return &event, ok, nil
+}
+
+func newAutoscalersHandler(t *testing.T) (*AutoscalersHandler, *storedAutoscalers) {
+	t.Helper()
+
+	deployments := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	err := deployments.Add(&appsV1.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "microservice",
+			Namespace: "application",
+			Labels:    map[string]string{"environment": "Dev"},
+			Annotations: map[string]string{
+				"dolittle.io/tenant-id":             "tenant",
+				"dolittle.io/application-id":        "application",
+				"dolittle.io/microservice-id":       "microservice",
+				"deployment.kubernetes.io/revision": "1",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	replicasets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	autoscalers := &storedAutoscalers{
+		autoscalers: map[entities.AutoscalerUID]entities.Autoscaler{},
+		events:      map[entities.ScaledEventUID]entities.ScaledEvent{},
+	}
+	handler := NewAutoscalersHandler(autoscalers, listersAppsV1.NewDeploymentLister(deployments), listersAppsV1.NewReplicaSetLister(replicasets), zerolog.Nop())
+	return handler, autoscalers
+}
+
+func newHorizontalPodAutoscaler(current, desired int32, scaled *time.Time) *autoscalingV2.HorizontalPodAutoscaler {
+	hpa := &autoscalingV2.HorizontalPodAutoscaler{
+		ObjectMeta: metaV1.ObjectMeta{Name: "microservice", Namespace: "application"},
+		Spec: autoscalingV2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingV2.CrossVersionObjectReference{Kind: "Deployment", Name: "microservice"},
+			MaxReplicas:    10,
+		},
+		Status: autoscalingV2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: current,
+			DesiredReplicas: desired,
+		},
+	}
+	if scaled != nil {
+		hpa.Status.LastScaleTime = &metaV1.Time{Time: *scaled}
+	}
+	return hpa
+}
+
+func TestCompletedRescaleIsRecordedFromTheReplicasObservedBefore(t *testing.T) {
+	handler, autoscalers := newAutoscalersHandler(t)
+	scaled := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := handler.Handle(newHorizontalPodAutoscaler(2, 2, nil), false); err != nil {
+		t.Fatal(err)
+	}
+	if err := handler.Handle(newHorizontalPodAutoscaler(5, 5, &scaled), false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(autoscalers.events) != 1 {
+		t.Fatalf("expected 1 scaled event, got %d", len(autoscalers.events))
+	}
+	for _, event := range autoscalers.events {
+		if !event.Properties.Time.Equal(scaled) || event.Properties.To != 5 || event.Properties.From == nil || *event.Properties.From != 2 {
+			t.Errorf("expected a scaled event from 2 to 5 at %v, got %+v", scaled, event.Properties)
+		}
+	}
+}
+
+func TestRescaleIsOnlyRecordedOnce(t *testing.T) {
+	handler, autoscalers := newAutoscalersHandler(t)
+	scaled := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := handler.Handle(newHorizontalPodAutoscaler(2, 5, &scaled), false); err != nil {
+		t.Fatal(err)
+	}
+	if err := handler.Handle(newHorizontalPodAutoscaler(5, 5, &scaled), false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(autoscalers.events) != 1 {
+		t.Fatalf("expected 1 scaled event, got %d", len(autoscalers.events))
+	}
+	for _, event := range autoscalers.events {
+		if event.Properties.To != 5 || event.Properties.From != nil {
+			t.Errorf("expected a scaled event to 5 from an unknown size, got %+v", event.Properties)
+		}
+	}
+}
@@ -5,7 +5,11 @@
 
 package observing
 
-import coreV1 "k8s.io/api/core/v1"
+import (
+	"dolittle.io/fleet-observer/entities"
+	coreV1 "k8s.io/api/core/v1"
+	"strings"
+)
 
 func getRuntimeAndHeadContainer(pod coreV1.PodSpec) (runtime, head coreV1.Container, ok bool) {
 	var hasHeadContainer, hasRuntimeContainer = false, false
@@ -24,3 +28,29 @@ func getRuntimeAndHeadContainer(pod coreV1.PodSpec) (runtime, head coreV1.Contai
 	ok = hasRuntimeContainer && hasHeadContainer
 	return
 }
+
+func getContainerResources(container coreV1.Container) entities.ContainerResources {
+	return entities.NewContainerResources(
+		container.Resources.Requests.Cpu().MilliValue(),
+		container.Resources.Limits.Cpu().MilliValue(),
+		container.Resources.Requests.Memory().Value(),
+		container.Resources.Limits.Memory().Value(),
+	)
+}
+
+func getContainerImageDigest(pod *coreV1.Pod, name string) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != name || status.ImageID == "" {
+			continue
+		}
+
+		if index := strings.LastIndex(status.ImageID, "@"); index >= 0 {
+			return status.ImageID[index+1:]
+		}
+		if index := strings.Index(status.ImageID, "://"); index >= 0 {
+			return status.ImageID[index+3:]
+		}
+		return status.ImageID
+	}
+	return ""
+}
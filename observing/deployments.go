@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/registry"
+	"dolittle.io/fleet-observer/storage"
+	"github.com/rs/zerolog"
+	coreV1 "k8s.io/api/core/v1"
+	"time"
+)
+
+// DeploymentWriter sets the Deployment entity, and the entities it is linked to, from the pod template of a workload
+type DeploymentWriter struct {
+	cluster      string
+	environments storage.Environments
+	artifacts    storage.Artifacts
+	runtimes     storage.Runtimes
+	deployments  storage.Deployments
+	parser       RuntimeVersionParser
+	releases     registry.ReleaseDateResolver
+}
+
+func NewDeploymentWriter(cluster string, environments storage.Environments, artifacts storage.Artifacts, runtimes storage.Runtimes, deployments storage.Deployments, parser RuntimeVersionParser, releases registry.ReleaseDateResolver) *DeploymentWriter {
+	return &DeploymentWriter{
+		cluster:      cluster,
+		environments: environments,
+		artifacts:    artifacts,
+		runtimes:     runtimes,
+		deployments:  deployments,
+		parser:       parser,
+		releases:     releases,
+	}
+}
+
+func (dw *DeploymentWriter) Set(tenantID, applicationID, environmentName, microserviceID, deploymentID, deploymentName string, created time.Time, template coreV1.PodSpec, logger zerolog.Logger) error {
+	runtimeContainer, headContainer, ok := getRuntimeAndHeadContainer(template)
+	if !ok {
+		return nil
+	}
+
+	resources := entities.NewResourceProfile(
+		getContainerResources(runtimeContainer),
+		getContainerResources(headContainer),
+	)
+
+	artifactVersionName := getArtifactVersionName(headContainer)
+	runtimeVersion, err := dw.parser.Parse(runtimeContainer.Image)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Using unknown runtime version because the runtime image could not be parsed")
+		runtimeVersion = entities.NewUnknownRuntimeVersion()
+	} else {
+		runtimeVersion.Properties.Released = dw.resolveReleaseDate(runtimeContainer.Image, func(released time.Time) error {
+			version := runtimeVersion
+			version.Properties.Released = &released
+			return dw.runtimes.SetVersion(version)
+		}, logger)
+	}
+
+	// -- Set all the entities --
+	environment := entities.NewEnvironment(tenantID, applicationID, environmentName, dw.cluster)
+	if err := dw.environments.Set(environment); err != nil {
+		return err
+	}
+	logger.Debug().Interface("environment", environment).Msg("Updated environment")
+
+	artifact := entities.NewArtifact(tenantID, microserviceID)
+	if err := dw.artifacts.Set(artifact); err != nil {
+		return err
+	}
+	logger.Debug().Interface("artifact", artifact).Msg("Updated artifact")
+
+	artifactVersion := entities.NewArtifactVersion(tenantID, microserviceID, artifactVersionName, nil)
+	artifactVersion.Properties.Released = dw.resolveReleaseDate(headContainer.Image, func(released time.Time) error {
+		version := artifactVersion
+		version.Properties.Released = &released
+		return dw.artifacts.SetVersion(version)
+	}, logger)
+	if err := dw.artifacts.SetVersion(artifactVersion); err != nil {
+		return err
+	}
+	logger.Debug().Interface("version", artifactVersion).Msg("Updated artifact version")
+
+	if err := dw.runtimes.SetVersion(runtimeVersion); err != nil {
+		return err
+	}
+	logger.Debug().Interface("version", runtimeVersion).Msg("Updated runtime version")
+
+	deployment := entities.NewDeployment(
+		tenantID,
+		applicationID,
+		environmentName,
+		deploymentID,
+		deploymentName,
+		created,
+		resources,
+		artifactVersion,
+		runtimeVersion,
+	)
+	if err := dw.deployments.Set(deployment); err != nil {
+		return err
+	}
+	logger.Debug().Interface("deployment", deployment).Msg("Updated deployment")
+
+	return nil
+}
+
+// resolveReleaseDate returns the release date of the image if it is known. If the resolver is asynchronous, the release date is
+// written using update when it is resolved later, so that the informer worker does not wait for the container registry.
+func (dw *DeploymentWriter) resolveReleaseDate(image string, update func(time.Time) error, logger zerolog.Logger) *time.Time {
+	resolve := dw.releases.ResolveReleaseDate
+	if async, ok := dw.releases.(registry.AsyncReleaseDateResolver); ok {
+		resolve = func(image string) (time.Time, bool, error) {
+			return async.ResolveReleaseDateAsync(image, func(released time.Time) {
+				if err := update(released); err != nil {
+					logger.Error().Err(err).Str("image", image).Msg("Failed to update resolved image release date")
+					return
+				}
+				logger.Debug().Str("image", image).Time("released", released).Msg("Updated resolved image release date")
+			})
+		}
+	}
+
+	released, found, err := resolve(image)
+	if err != nil {
+		logger.Warn().Err(err).Str("image", image).Msg("Failed to resolve image release date")
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	return &released
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+// setExposedEndpoints sets the endpoints currently exposed by the Ingress or Service with the kind and name,
+// and marks the endpoints it exposed before that it no longer exposes as deleted. All the endpoints of a deleted resource are marked as deleted.
+func setExposedEndpoints(repository storage.Endpoints, environment entities.EnvironmentUID, kind, name string, endpoints []entities.Endpoint, deleted bool, logger zerolog.Logger) error {
+	previous, err := repository.ListExposedIn(environment)
+	if err != nil {
+		return err
+	}
+
+	exposed := map[entities.EndpointUID]bool{}
+	if !deleted {
+		for _, endpoint := range endpoints {
+			exposed[endpoint.UID] = true
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, endpoint := range previous {
+		if endpoint.Properties.Kind != kind || endpoint.Properties.Name != name || endpoint.Properties.Deleted != nil || exposed[endpoint.UID] {
+			continue
+		}
+
+		endpoint.Properties.Deleted = &now
+		if err := repository.Set(endpoint); err != nil {
+			return err
+		}
+		logger.Debug().Interface("endpoint", endpoint).Msg("Marked endpoint as deleted")
+	}
+
+	if deleted {
+		return nil
+	}
+
+	for _, endpoint := range endpoints {
+		if err := repository.Set(endpoint); err != nil {
+			return err
+		}
+		logger.Debug().Interface("endpoint", endpoint).Msg("Updated endpoint")
+	}
+	return nil
+}
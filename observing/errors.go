@@ -23,3 +23,7 @@ func ReceivedWrongType(received any, expected string) error {
 func FailedToParseRuntimeVersion(image string) error {
 	return fmt.Errorf("%w: %v", CouldNotParseRuntimeVersion, image)
 }
+
+func FailedToResolveRuntimeVersion(image string, err error) error {
+	return fmt.Errorf("%w: %v: %v", CouldNotParseRuntimeVersion, image, err)
+}
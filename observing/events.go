@@ -12,22 +12,38 @@ import (
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	listersAppsV1 "k8s.io/client-go/listers/apps/v1"
+	listersAutoscalingV2 "k8s.io/client-go/listers/autoscaling/v2"
+	listersBatchV1 "k8s.io/client-go/listers/batch/v1"
 	listersCoreV1 "k8s.io/client-go/listers/core/v1"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
+var rescaleMessageExpression = regexp.MustCompile(`^New size: (\d+); reason: (.*)$`)
+
 type EventsHandler struct {
 	events      storage.Events
+	autoscalers storage.Autoscalers
 	pods        listersCoreV1.PodLister
 	replicasets listersAppsV1.ReplicaSetLister
+	jobs        listersBatchV1.JobLister
+	cronjobs    listersBatchV1.CronJobLister
+	hpas        listersAutoscalingV2.HorizontalPodAutoscalerLister
+	deployments listersAppsV1.DeploymentLister
 	logger      zerolog.Logger
 }
 
-func NewEventsHandler(events storage.Events, pods listersCoreV1.PodLister, replicasets listersAppsV1.ReplicaSetLister, logger zerolog.Logger) *EventsHandler {
+func NewEventsHandler(events storage.Events, autoscalers storage.Autoscalers, pods listersCoreV1.PodLister, replicasets listersAppsV1.ReplicaSetLister, jobs listersBatchV1.JobLister, cronjobs listersBatchV1.CronJobLister, hpas listersAutoscalingV2.HorizontalPodAutoscalerLister, deployments listersAppsV1.DeploymentLister, logger zerolog.Logger) *EventsHandler {
 	return &EventsHandler{
 		events:      events,
+		autoscalers: autoscalers,
 		pods:        pods,
 		replicasets: replicasets,
+		jobs:        jobs,
+		cronjobs:    cronjobs,
+		hpas:        hpas,
+		deployments: deployments,
 		logger:      logger,
 	}
 }
@@ -39,6 +55,9 @@ func (eh *EventsHandler) Handle(obj any, _deleted bool) error {
 	}
 
 	logger := eh.logger.With().Str("namespace", event.GetNamespace()).Str("name", event.GetName()).Logger()
+	if event.InvolvedObject.Kind == "HorizontalPodAutoscaler" {
+		return eh.handleAutoscalerEvent(event, logger)
+	}
 	if event.InvolvedObject.Kind != "Pod" {
 		logger.Trace().Msg("Skipping event because it does not involve a pod")
 		return nil
@@ -64,12 +83,11 @@ func (eh *EventsHandler) Handle(obj any, _deleted bool) error {
 		return nil
 	}
 
-	replicaset, err := GetPodOwner(pod, eh.replicasets)
+	owner, ok, err := GetPodInstanceOwner(pod, eh.replicasets, eh.jobs, eh.cronjobs)
 	if err != nil {
-		logger.Trace().Msg("Skipping event because the pod owner could not be found")
+		logger.Trace().Err(err).Msg("Skipping event because the pod owner could not be found")
 		return nil
 	}
-	revision, ok := replicaset.GetAnnotations()["deployment.kubernetes.io/revision"]
 	if !ok {
 		logger.Trace().Msg("Skipping event because the replicaset does not have a revision annotation")
 		return nil
@@ -79,8 +97,8 @@ func (eh *EventsHandler) Handle(obj any, _deleted bool) error {
 		tenantID,
 		applicationID,
 		environmentName,
-		revision,
-		string(pod.GetUID()),
+		owner.DeploymentID,
+		owner.InstanceID,
 	)
 
 	return eh.handleDeploymentInstanceEvent(instanceUID, event, logger)
@@ -150,3 +168,56 @@ func (eh *EventsHandler) handleBackOffEvent(id entities.DeploymentInstanceUID, e
 	logger.Warn().Str("eventMessage", event.Message).Msg("Skipping BackOff event with unhandled message")
 	return nil
 }
+
+func (eh *EventsHandler) handleAutoscalerEvent(event *coreV1.Event, logger zerolog.Logger) error {
+	if event.Reason != "SuccessfulRescale" {
+		logger.Trace().Str("reason", event.Reason).Msg("Skipping autoscaler event with unhandled reason")
+		return nil
+	}
+
+	match := rescaleMessageExpression.FindStringSubmatch(event.Message)
+	if match == nil {
+		logger.Warn().Str("eventMessage", event.Message).Msg("Skipping SuccessfulRescale event with unhandled message")
+		return nil
+	}
+	size, err := strconv.Atoi(match[1])
+	if err != nil {
+		logger.Warn().Str("eventMessage", event.Message).Msg("Skipping SuccessfulRescale event with unhandled message")
+		return nil
+	}
+
+	if eh.hpas == nil {
+		logger.Trace().Msg("Skipping event because autoscalers are not observed")
+		return nil
+	}
+
+	hpa, err := eh.hpas.HorizontalPodAutoscalers(event.InvolvedObject.Namespace).Get(event.InvolvedObject.Name)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Trace().Err(err).Msg("Skipping event because the autoscaler no longer exists")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	scaled := event.LastTimestamp.UTC()
+	if event.LastTimestamp.IsZero() {
+		scaled = event.EventTime.UTC()
+	}
+
+	deploymentUID, ok, err := GetScaledDeploymentUIDAt(hpa, scaled, eh.deployments, eh.replicasets)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		logger.Trace().Msg("Skipping event because the autoscaler does not scale a deployment with microservice identifiers")
+		return nil
+	}
+
+	scaledEvent := entities.NewScaledEvent(deploymentUID, scaled, nil, size, match[2])
+	if err := SetScaledEvent(eh.autoscalers, scaledEvent); err != nil {
+		return err
+	}
+	logger.Debug().Interface("event", scaledEvent).Msg("Updated scaled event")
+
+	return nil
+}
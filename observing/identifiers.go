@@ -5,7 +5,12 @@
 
 package observing
 
-import metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"dolittle.io/fleet-observer/entities"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listersAppsV1 "k8s.io/client-go/listers/apps/v1"
+)
 
 func GetMicroserviceIdentifiers(meta metaV1.ObjectMeta) (tenantID, applicationID, environmentName, microserviceID string, ok bool) {
 	tenantID, ok = meta.GetAnnotations()["dolittle.io/tenant-id"]
@@ -30,3 +35,47 @@ func GetMicroserviceIdentifiers(meta metaV1.ObjectMeta) (tenantID, applicationID
 
 	return
 }
+
+func GetEnvironmentIdentifiers(meta metaV1.ObjectMeta) (tenantID, applicationID, environmentName string, ok bool) {
+	tenantID, ok = meta.GetAnnotations()["dolittle.io/tenant-id"]
+	if !ok {
+		return
+	}
+
+	applicationID, ok = meta.GetAnnotations()["dolittle.io/application-id"]
+	if !ok {
+		return
+	}
+
+	environmentName, ok = meta.GetLabels()["environment"]
+	if !ok {
+		return
+	}
+
+	return
+}
+
+func GetDeploymentInstanceUID(pod *coreV1.Pod, replicasets listersAppsV1.ReplicaSetLister) (entities.DeploymentInstanceUID, bool) {
+	tenantID, applicationID, environmentName, _, ok := GetMicroserviceIdentifiers(pod.ObjectMeta)
+	if !ok {
+		return "", false
+	}
+
+	replicaset, err := GetPodOwner(pod, replicasets)
+	if err != nil {
+		return "", false
+	}
+
+	revision, ok := replicaset.GetAnnotations()["deployment.kubernetes.io/revision"]
+	if !ok {
+		return "", false
+	}
+
+	return entities.NewDeploymentInstanceUID(
+		tenantID,
+		applicationID,
+		environmentName,
+		revision,
+		string(pod.GetUID()),
+	), true
+}
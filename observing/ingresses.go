@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+	"github.com/rs/zerolog"
+	networkingV1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	listersCoreV1 "k8s.io/client-go/listers/core/v1"
+	"strings"
+)
+
+type IngressesHandler struct {
+	endpoints storage.Endpoints
+	services  listersCoreV1.ServiceLister
+	logger    zerolog.Logger
+}
+
+func NewIngressesHandler(endpoints storage.Endpoints, services listersCoreV1.ServiceLister, logger zerolog.Logger) *IngressesHandler {
+	return &IngressesHandler{
+		endpoints: endpoints,
+		services:  services,
+		logger:    logger.With().Str("handler", "ingresses").Logger(),
+	}
+}
+
+func (ih *IngressesHandler) Handle(obj any, deleted bool) error {
+	ingress, ok := obj.(*networkingV1.Ingress)
+	if !ok {
+		return ReceivedWrongType(obj, "Ingress")
+	}
+
+	logger := ih.logger.With().Str("namespace", ingress.GetNamespace()).Str("name", ingress.GetName()).Logger()
+
+	tenantID, applicationID, environmentName, ok := GetEnvironmentIdentifiers(ingress.ObjectMeta)
+	if !ok {
+		logger.Trace().Msg("Skipping ingress because it is missing environment identifiers")
+		return nil
+	}
+
+	tlsHosts := map[string]bool{}
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+	public := isPublicIngress(ingress)
+
+	var endpoints []entities.Endpoint
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			microserviceID, err := ih.getBackendMicroserviceID(ingress, path.Backend)
+			if err != nil {
+				return err
+			}
+
+			tls := tlsHosts[rule.Host]
+			port := 80
+			if tls {
+				port = 443
+			}
+
+			endpoint := entities.NewEndpoint(
+				tenantID,
+				applicationID,
+				environmentName,
+				entities.IngressEndpointKind,
+				ingress.GetName(),
+				rule.Host,
+				path.Path,
+				port,
+				tls,
+				public,
+				microserviceID,
+			)
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	environment := entities.NewEnvironmentUID(tenantID, applicationID, environmentName)
+	return setExposedEndpoints(ih.endpoints, environment, entities.IngressEndpointKind, ingress.GetName(), endpoints, deleted, logger)
+}
+
+func (ih *IngressesHandler) getBackendMicroserviceID(ingress *networkingV1.Ingress, backend networkingV1.IngressBackend) (string, error) {
+	if backend.Service != nil {
+		service, err := ih.services.Services(ingress.GetNamespace()).Get(backend.Service.Name)
+		if err != nil && !errors.IsNotFound(err) {
+			return "", err
+		}
+		if err == nil {
+			if microserviceID, ok := service.GetAnnotations()["dolittle.io/microservice-id"]; ok {
+				return microserviceID, nil
+			}
+		}
+	}
+
+	return ingress.GetAnnotations()["dolittle.io/microservice-id"], nil
+}
+
+// isPublicIngress returns whether the ingress class of the ingress is public or internal, or nil if it cannot be determined from the ingress class
+func isPublicIngress(ingress *networkingV1.Ingress) *bool {
+	class := ingress.GetAnnotations()["kubernetes.io/ingress.class"]
+	if ingress.Spec.IngressClassName != nil {
+		class = *ingress.Spec.IngressClassName
+	}
+
+	public, internal := true, false
+	class = strings.ToLower(class)
+	switch {
+	case strings.Contains(class, "internal") || strings.Contains(class, "private"):
+		return &internal
+	case strings.Contains(class, "public") || strings.Contains(class, "external"):
+		return &public
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,205 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"crypto/sha256"
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/registry"
+	"dolittle.io/fleet-observer/storage"
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listersBatchV1 "k8s.io/client-go/listers/batch/v1"
+	"time"
+)
+
+type JobsHandler struct {
+	writer      *DeploymentWriter
+	deployments storage.Deployments
+	cronjobs    listersBatchV1.CronJobLister
+	logger      zerolog.Logger
+}
+
+func NewJobsHandler(cluster string, environments storage.Environments, artifacts storage.Artifacts, runtimes storage.Runtimes, deployments storage.Deployments, parser RuntimeVersionParser, releases registry.ReleaseDateResolver, cronjobs listersBatchV1.CronJobLister, logger zerolog.Logger) *JobsHandler {
+	return &JobsHandler{
+		writer:      NewDeploymentWriter(cluster, environments, artifacts, runtimes, deployments, parser, releases),
+		deployments: deployments,
+		cronjobs:    cronjobs,
+		logger:      logger.With().Str("handler", "jobs").Logger(),
+	}
+}
+
+func (jh *JobsHandler) Handle(obj any, deleted bool) error {
+	job, ok := obj.(*batchV1.Job)
+	if !ok {
+		return ReceivedWrongType(obj, "Job")
+	}
+
+	logger := jh.logger.With().Str("namespace", job.GetNamespace()).Str("name", job.GetName()).Logger()
+
+	definition, deploymentID, err := GetJobDefinition(job, jh.cronjobs)
+	if err != nil {
+		return err
+	}
+
+	tenantID, applicationID, environmentName, microserviceID, ok := GetMicroserviceIdentifiers(definition)
+	if !ok {
+		logger.Trace().Msg("Skipping job because it is missing microservice identifiers")
+		return nil
+	}
+
+	deploymentName, ok := definition.GetLabels()["microservice"]
+	if !ok {
+		logger.Trace().Msg("Skipping job because it does not have a microservice label")
+		return nil
+	}
+
+	if _, _, ok := getRuntimeAndHeadContainer(job.Spec.Template.Spec); !ok {
+		logger.Trace().Msg("Skipping job because it does not have a runtime and head container")
+		return nil
+	}
+
+	err = jh.writer.Set(
+		tenantID,
+		applicationID,
+		environmentName,
+		microserviceID,
+		deploymentID,
+		deploymentName,
+		definition.GetCreationTimestamp().UTC(),
+		job.Spec.Template.Spec,
+		logger,
+	)
+	if err != nil {
+		return err
+	}
+
+	instanceID := entities.NewDeploymentInstanceUID(
+		tenantID,
+		applicationID,
+		environmentName,
+		deploymentID,
+		string(job.GetUID()),
+	)
+	instance, exists, err := jh.deployments.GetInstance(instanceID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		logger.Trace().Msg("Skipping job status because the deployment instance does not exist yet")
+		return nil
+	}
+
+	setJobStatus(instance, job, deleted)
+	if err := jh.deployments.SetInstance(*instance); err != nil {
+		return err
+	}
+	logger.Debug().Interface("instance", instance).Msg("Updated deployment instance")
+
+	return nil
+}
+
+// GetJobDefinition finds the object that defines a job, which is the owning CronJob for scheduled jobs and the Job itself otherwise,
+// and the deployment ID of the version of the definition that the job was created from. The version of a CronJob is identified by
+// the hash of the pod template of the job, so that older jobs stay with their version when the CronJob is changed.
+func GetJobDefinition(job *batchV1.Job, cronjobs listersBatchV1.CronJobLister) (metaV1.ObjectMeta, string, error) {
+	for _, owner := range job.GetOwnerReferences() {
+		if owner.Kind != "CronJob" {
+			continue
+		}
+
+		cronjob, err := cronjobs.CronJobs(job.GetNamespace()).Get(owner.Name)
+		if errors.IsNotFound(err) {
+			break
+		}
+		if err != nil {
+			return metaV1.ObjectMeta{}, "", err
+		}
+
+		hash, err := getJobTemplateHash(job)
+		if err != nil {
+			return metaV1.ObjectMeta{}, "", err
+		}
+		return cronjob.ObjectMeta, fmt.Sprintf("cronjob-%v-%v", cronjob.GetName(), hash), nil
+	}
+
+	return job.ObjectMeta, fmt.Sprintf("job-%v", job.GetName()), nil
+}
+
+// getJobTemplateHash returns a short hash of the pod spec of the job. The labels of the pod template are left out,
+// since the job controller adds the name and UID of the job to them.
+func getJobTemplateHash(job *batchV1.Job) (string, error) {
+	spec, err := json.Marshal(job.Spec.Template.Spec)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(spec)
+	return fmt.Sprintf("%x", hash[:5]), nil
+}
+
+func GetPodJob(pod *coreV1.Pod, jobs listersBatchV1.JobLister) (*batchV1.Job, error) {
+	for _, owner := range pod.GetOwnerReferences() {
+		if owner.Kind == "Job" {
+			if job, err := jobs.Jobs(pod.GetNamespace()).Get(owner.Name); err == nil {
+				return job, nil
+			}
+		}
+	}
+	return nil, PodOwnerNotFound
+}
+
+func getJobStarted(job *batchV1.Job) time.Time {
+	if job.Status.StartTime != nil {
+		return job.Status.StartTime.UTC()
+	}
+	return job.GetCreationTimestamp().UTC()
+}
+
+// setJobStatus sets the status of an instance of a job from the conditions of the job.
+// The job decides when the instance is stopped, since its pods can be retried, so instances of jobs are not stopped by cleanup.
+// A job that is deleted before it has finished is stopped when deleted, and has failed since its pods are deleted with it.
+func setJobStatus(instance *entities.DeploymentInstance, job *batchV1.Job, deleted bool) {
+	instance.Properties.Started = getJobStarted(job)
+	instance.Properties.Status = entities.RunningDeploymentInstanceStatus
+	instance.Properties.Stopped = nil
+	instance.Properties.Duration = nil
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != coreV1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchV1.JobComplete:
+			instance.Properties.Status = entities.SucceededDeploymentInstanceStatus
+			stopped := condition.LastTransitionTime.UTC()
+			if job.Status.CompletionTime != nil {
+				stopped = job.Status.CompletionTime.UTC()
+			}
+			instance.Properties.Stopped = &stopped
+		case batchV1.JobFailed:
+			instance.Properties.Status = entities.FailedDeploymentInstanceStatus
+			stopped := condition.LastTransitionTime.UTC()
+			instance.Properties.Stopped = &stopped
+		}
+	}
+
+	if instance.Properties.Stopped == nil && deleted {
+		instance.Properties.Status = entities.FailedDeploymentInstanceStatus
+		stopped := time.Now().UTC()
+		instance.Properties.Stopped = &stopped
+	}
+
+	if instance.Properties.Stopped != nil {
+		duration := int(instance.Properties.Stopped.Sub(instance.Properties.Started).Seconds())
+		instance.Properties.Duration = &duration
+	}
+}
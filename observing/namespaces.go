@@ -10,12 +10,18 @@ import (
 	"dolittle.io/fleet-observer/storage"
 	"github.com/rs/zerolog"
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sync"
+	"time"
 )
 
 type NamespacesHandler struct {
 	customers    storage.Customers
 	applications storage.Applications
 	logger       zerolog.Logger
+
+	lock         sync.Mutex
+	tenantLabels map[types.UID]string
 }
 
 func NewNamespacesHandler(customers storage.Customers, applications storage.Applications, logger zerolog.Logger) *NamespacesHandler {
@@ -23,10 +29,11 @@ func NewNamespacesHandler(customers storage.Customers, applications storage.Appl
 		customers:    customers,
 		applications: applications,
 		logger:       logger.With().Str("handler", "namespaces").Logger(),
+		tenantLabels: map[types.UID]string{},
 	}
 }
 
-func (nh *NamespacesHandler) Handle(obj any, _deleted bool) error {
+func (nh *NamespacesHandler) Handle(obj any, deleted bool) error {
 	namespace, ok := obj.(*coreV1.Namespace)
 	if !ok {
 		return ReceivedWrongType(obj, "Namespace")
@@ -45,8 +52,52 @@ func (nh *NamespacesHandler) Handle(obj any, _deleted bool) error {
 		return nil
 	}
 
-	tenantName := namespace.GetLabels()["tenant"]
-	applicationName := namespace.GetLabels()["application"]
+	now := time.Now().UTC()
+	created := namespace.GetCreationTimestamp().UTC()
+
+	if err := nh.handleCustomer(namespace.GetUID(), tenantID, namespace.GetLabels()["tenant"], created, deleted, now, logger); err != nil {
+		return err
+	}
+
+	return nh.handleApplication(tenantID, applicationID, namespace.GetLabels()["application"], created, deleted, now, logger)
+}
+
+// handleCustomer sets the customer and its current name. Since the namespaces of a customer can have different tenant labels,
+// a new name is only recorded when the tenant label of a namespace is changed, or a namespace is created after the current name was recorded.
+// The tenant labels of namespaces that were observed before, or that are deleted, do not change the current name.
+func (nh *NamespacesHandler) handleCustomer(namespaceUID types.UID, tenantID, tenantLabel string, created time.Time, deleted bool, now time.Time, logger zerolog.Logger) error {
+	customerID := entities.NewCustomerUID(tenantID)
+	labelChanged := nh.updateTenantLabel(namespaceUID, tenantLabel, deleted)
+
+	current, hasCurrent, err := nh.customers.GetCurrentName(customerID)
+	if err != nil {
+		return err
+	}
+
+	tenantName := tenantLabel
+	switch {
+	case tenantLabel == "" || deleted:
+		tenantName = ""
+	case !hasCurrent || current.Properties.Name == tenantLabel:
+	case labelChanged || created.After(current.Properties.From):
+	default:
+		logger.Trace().Str("label", tenantLabel).Msg("Keeping current customer name because the tenant label of the namespace has not changed")
+		tenantName = ""
+	}
+
+	if tenantName == "" && hasCurrent {
+		tenantName = current.Properties.Name
+	}
+	if tenantName == "" {
+		previous, exists, err := nh.customers.Get(customerID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			logger.Trace().Msg("Keeping previous customer name because the namespace does not have a tenant label")
+			tenantName = previous.Properties.Name
+		}
+	}
 
 	customer := entities.NewCustomer(tenantID, tenantName)
 	if err := nh.customers.Set(customer); err != nil {
@@ -54,11 +105,96 @@ func (nh *NamespacesHandler) Handle(obj any, _deleted bool) error {
 	}
 	logger.Debug().Interface("customer", customer).Msg("Updated customer")
 
-	application := entities.NewApplication(tenantID, applicationID, applicationName)
+	if tenantName == "" || (hasCurrent && current.Properties.Name == tenantName) {
+		return nil
+	}
+
+	from := created
+	if hasCurrent {
+		current.Properties.To = &now
+		if err := nh.customers.SetName(*current); err != nil {
+			return err
+		}
+		logger.Debug().Interface("name", current).Msg("Updated customer name")
+		from = now
+	}
+
+	name := entities.NewCustomerName(tenantID, tenantName, from)
+	if err := nh.customers.SetName(name); err != nil {
+		return err
+	}
+	logger.Debug().Interface("name", name).Msg("Updated customer name")
+
+	return nil
+}
+
+// updateTenantLabel stores the tenant label of the namespace, and returns true if it was changed since the namespace was last observed
+func (nh *NamespacesHandler) updateTenantLabel(namespaceUID types.UID, tenantLabel string, deleted bool) bool {
+	nh.lock.Lock()
+	defer nh.lock.Unlock()
+
+	previous, observed := nh.tenantLabels[namespaceUID]
+	if deleted {
+		delete(nh.tenantLabels, namespaceUID)
+		return false
+	}
+	nh.tenantLabels[namespaceUID] = tenantLabel
+	return observed && previous != tenantLabel
+}
+
+func (nh *NamespacesHandler) handleApplication(tenantID, applicationID, applicationName string, created time.Time, deleted bool, now time.Time, logger zerolog.Logger) error {
+	applicationUID := entities.NewApplicationUID(tenantID, applicationID)
+
+	previous, exists, err := nh.applications.Get(applicationUID)
+	if err != nil {
+		return err
+	}
+	if applicationName == "" && exists {
+		logger.Trace().Msg("Keeping previous application name because the namespace does not have an application label")
+		applicationName = previous.Properties.Name
+	}
+
+	var deletedTime *time.Time
+	if deleted {
+		deletedTime = &now
+		if exists && previous.Properties.Deleted != nil && previous.Properties.Created.Equal(created) {
+			deletedTime = previous.Properties.Deleted
+		}
+	}
+
+	application := entities.NewApplication(tenantID, applicationID, applicationName, created, deletedTime)
 	if err := nh.applications.Set(application); err != nil {
 		return err
 	}
 	logger.Debug().Interface("application", application).Msg("Updated application")
 
+	if applicationName == "" {
+		return nil
+	}
+
+	current, hasCurrent, err := nh.applications.GetCurrentName(applicationUID)
+	if err != nil {
+		return err
+	}
+	if hasCurrent && current.Properties.Name == applicationName {
+		return nil
+	}
+
+	from := created
+	if hasCurrent {
+		current.Properties.To = &now
+		if err := nh.applications.SetName(*current); err != nil {
+			return err
+		}
+		logger.Debug().Interface("name", current).Msg("Updated application name")
+		from = now
+	}
+
+	name := entities.NewApplicationName(tenantID, applicationID, applicationName, from)
+	if err := nh.applications.SetName(name); err != nil {
+		return err
+	}
+	logger.Debug().Interface("name", name).Msg("Updated application name")
+
 	return nil
 }
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	coreV1 "k8s.io/api/core/v1"
+	"time"
+)
+
+// nodeCondition maps a Kubernetes node condition to the entities.NodeEvent type that is recorded
+// while the condition has the unhealthy status
+type nodeCondition struct {
+	conditionType   coreV1.NodeConditionType
+	unhealthyStatus coreV1.ConditionStatus
+	eventType       string
+}
+
+var nodeConditions = []nodeCondition{
+	{coreV1.NodeReady, coreV1.ConditionFalse, entities.NodeNotReadyEventType},
+	{coreV1.NodeMemoryPressure, coreV1.ConditionTrue, entities.NodeMemoryPressureEventType},
+	{coreV1.NodeDiskPressure, coreV1.ConditionTrue, entities.NodeDiskPressureEventType},
+	{coreV1.NodePIDPressure, coreV1.ConditionTrue, entities.NodePIDPressureEventType},
+	{coreV1.NodeNetworkUnavailable, coreV1.ConditionTrue, entities.NodeNetworkUnavailableEventType},
+}
+
+// nodeState is the current state of a node for one of the tracked event types
+type nodeState struct {
+	active bool
+	since  time.Time
+	reason string
+}
+
+func getNodeStates(node *coreV1.Node, now time.Time) map[string]nodeState {
+	states := map[string]nodeState{}
+
+	for _, tracked := range nodeConditions {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type != tracked.conditionType {
+				continue
+			}
+
+			since := condition.LastTransitionTime.UTC()
+			if condition.LastTransitionTime.IsZero() {
+				since = now
+			}
+
+			// An unknown Ready condition means that the node controller has lost contact with the kubelet
+			active := condition.Status == tracked.unhealthyStatus
+			if tracked.conditionType == coreV1.NodeReady && condition.Status == coreV1.ConditionUnknown {
+				active = true
+			}
+
+			states[tracked.eventType] = nodeState{active, since, condition.Reason}
+		}
+	}
+
+	unschedulable := nodeState{active: node.Spec.Unschedulable, since: now}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == coreV1.TaintNodeUnschedulable && taint.TimeAdded != nil {
+			unschedulable.since = taint.TimeAdded.UTC()
+		}
+	}
+	states[entities.NodeUnschedulableEventType] = unschedulable
+
+	return states
+}
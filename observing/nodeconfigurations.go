@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"crypto/sha512"
+	"dolittle.io/fleet-observer/entities"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// getNodeConfiguration creates a snapshot of the attributes of a node that can change over its lifetime,
+// keyed by a hash of the contents so that each distinct combination is stored once per node
+func getNodeConfiguration(node entities.Node) (entities.NodeConfiguration, error) {
+	configuration := entities.NewNodeConfiguration(node, "")
+
+	// the times the configuration was observed are not part of the contents
+	contents, err := json.Marshal(struct {
+		ContentHash string `json:"hash"`
+		Image       string `json:"image"`
+		Type        string `json:"type"`
+		entities.NodeInfo
+		Capacity entities.NodeCapacity `json:"capacity"`
+	}{
+		ContentHash: configuration.Properties.ContentHash,
+		Image:       configuration.Properties.Image,
+		Type:        configuration.Properties.Type,
+		NodeInfo:    configuration.Properties.NodeInfo,
+		Capacity:    configuration.Properties.Capacity,
+	})
+	if err != nil {
+		return configuration, err
+	}
+
+	return entities.NewNodeConfiguration(node, fmt.Sprintf("%x", sha512.Sum512(contents))), nil
+}
+
+// getNodeConfigurationAt returns the configuration that the node had at the time, or false if none of the configurations were observed then.
+// A node can return to a previous configuration, which is then observed from the first time it was seen, so the configuration that was observed last is used.
+func getNodeConfigurationAt(configurations []entities.NodeConfiguration, at time.Time) (entities.NodeConfiguration, bool) {
+	var found entities.NodeConfiguration
+	var ok bool
+	for _, configuration := range configurations {
+		if configuration.Properties.ObservedFrom.After(at) {
+			continue
+		}
+		if configuration.Properties.ObservedTo != nil && configuration.Properties.ObservedTo.Before(at) {
+			continue
+		}
+		if !ok || configuration.Properties.ObservedFrom.After(found.Properties.ObservedFrom) {
+			found = configuration
+			ok = true
+		}
+	}
+	return found, ok
+}
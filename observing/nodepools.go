@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	listersCoreV1 "k8s.io/client-go/listers/core/v1"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	autoscalerStatusNamespace = "kube-system"
+	autoscalerStatusName      = "cluster-autoscaler-status"
+	autoscalerStatusKey       = "status"
+)
+
+var (
+	autoscalerNodeGroupNameExpression = regexp.MustCompile(`^\s*Name:\s*(\S+)\s*$`)
+	autoscalerNodeGroupSizeExpression = regexp.MustCompile(`minSize=(\d+),\s*maxSize=(\d+)`)
+)
+
+// getNodePoolSize looks up the minimum and maximum size of a node pool from the status reported by the cluster-autoscaler.
+// The node group names are provider specific, so a node group is considered to match when its name is the pool name, or when it
+// contains the pool name delimited by non-alphanumeric characters (like "aks-pool1-12345678-vmss") and no other node group does.
+func getNodePoolSize(configmaps listersCoreV1.ConfigMapLister, pool string) (*int, *int, error) {
+	configmap, err := configmaps.ConfigMaps(autoscalerStatusNamespace).Get(autoscalerStatusName)
+	if errors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	minSize, maxSize, _ := parseAutoscalerNodeGroupSize(configmap, pool)
+	return minSize, maxSize, nil
+}
+
+type autoscalerNodeGroup struct {
+	name    string
+	minSize int
+	maxSize int
+}
+
+func parseAutoscalerNodeGroupSize(configmap *coreV1.ConfigMap, pool string) (*int, *int, bool) {
+	if pool == "" {
+		return nil, nil, false
+	}
+
+	delimited := regexp.MustCompile(`(^|[^A-Za-z0-9])` + regexp.QuoteMeta(pool) + `([^A-Za-z0-9]|$)`)
+	var matching []autoscalerNodeGroup
+	for _, group := range parseAutoscalerNodeGroups(configmap) {
+		if group.name == pool {
+			return &group.minSize, &group.maxSize, true
+		}
+		if delimited.MatchString(group.name) {
+			matching = append(matching, group)
+		}
+	}
+
+	if len(matching) != 1 {
+		return nil, nil, false
+	}
+	return &matching[0].minSize, &matching[0].maxSize, true
+}
+
+func parseAutoscalerNodeGroups(configmap *coreV1.ConfigMap) []autoscalerNodeGroup {
+	var groups []autoscalerNodeGroup
+	name := ""
+	for _, line := range strings.Split(configmap.Data[autoscalerStatusKey], "\n") {
+		if match := autoscalerNodeGroupNameExpression.FindStringSubmatch(line); match != nil {
+			name = match[1]
+			continue
+		}
+		if name == "" {
+			continue
+		}
+
+		match := autoscalerNodeGroupSizeExpression.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		minSize, minErr := strconv.Atoi(match[1])
+		maxSize, maxErr := strconv.Atoi(match[2])
+		if minErr == nil && maxErr == nil {
+			groups = append(groups, autoscalerNodeGroup{name: name, minSize: minSize, maxSize: maxSize})
+		}
+		name = ""
+	}
+	return groups
+}
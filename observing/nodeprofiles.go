@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	coreV1 "k8s.io/api/core/v1"
+	"strings"
+)
+
+// nodeProfile describes how to recognise nodes from a specific cloud provider,
+// and which provider specific labels to read the node metadata from
+type nodeProfile struct {
+	provider         string
+	providerIDPrefix string
+	labels           []string
+	imageLabels      []string
+	poolLabels       []string
+	modeLabels       []string
+}
+
+var nodeProfiles = []nodeProfile{
+	{
+		provider:         "aks",
+		providerIDPrefix: "azure://",
+		labels:           []string{"kubernetes.azure.com/cluster", "kubernetes.azure.com/agentpool"},
+		imageLabels:      []string{"kubernetes.azure.com/node-image-version"},
+		poolLabels:       []string{"kubernetes.azure.com/agentpool", "agentpool"},
+		modeLabels:       []string{"kubernetes.azure.com/mode"},
+	},
+	{
+		provider:         "eks",
+		providerIDPrefix: "aws://",
+		labels:           []string{"eks.amazonaws.com/nodegroup", "alpha.eksctl.io/cluster-name"},
+		imageLabels:      []string{"eks.amazonaws.com/nodegroup-image"},
+		poolLabels:       []string{"eks.amazonaws.com/nodegroup", "alpha.eksctl.io/nodegroup-name"},
+	},
+	{
+		provider:         "gke",
+		providerIDPrefix: "gce://",
+		labels:           []string{"cloud.google.com/gke-nodepool"},
+		imageLabels:      []string{"cloud.google.com/gke-os-distribution"},
+		poolLabels:       []string{"cloud.google.com/gke-nodepool"},
+	},
+	{
+		provider:         "kind",
+		providerIDPrefix: "kind://",
+	},
+}
+
+var genericNodeProfile = nodeProfile{
+	provider:   "generic",
+	poolLabels: []string{"karpenter.sh/nodepool", "kops.k8s.io/instancegroup", "node.kubernetes.io/pool"},
+}
+
+func getNodeProfile(node *coreV1.Node) nodeProfile {
+	for _, profile := range nodeProfiles {
+		if profile.providerIDPrefix != "" && strings.HasPrefix(node.Spec.ProviderID, profile.providerIDPrefix) {
+			return profile
+		}
+		if _, ok := getFirstLabel(node, profile.labels...); ok {
+			return profile
+		}
+	}
+	return genericNodeProfile
+}
+
+func (p nodeProfile) getHostname(node *coreV1.Node) string {
+	if hostname, ok := getFirstLabel(node, "kubernetes.io/hostname"); ok {
+		return hostname
+	}
+	return node.GetName()
+}
+
+func (p nodeProfile) getImage(node *coreV1.Node) string {
+	if image, ok := getFirstLabel(node, p.imageLabels...); ok {
+		return image
+	}
+	return node.Status.NodeInfo.OSImage
+}
+
+func (p nodeProfile) getInstanceType(node *coreV1.Node) string {
+	instanceType, _ := getFirstLabel(node, "node.kubernetes.io/instance-type", "beta.kubernetes.io/instance-type")
+	return instanceType
+}
+
+func (p nodeProfile) getPoolName(node *coreV1.Node) (string, bool) {
+	if name, ok := getFirstLabel(node, p.poolLabels...); ok {
+		return name, true
+	}
+	return getFirstLabel(node, genericNodeProfile.poolLabels...)
+}
+
+func (p nodeProfile) getPoolMode(node *coreV1.Node) string {
+	mode, _ := getFirstLabel(node, p.modeLabels...)
+	return mode
+}
+
+func (p nodeProfile) getInfo(node *coreV1.Node) entities.NodeInfo {
+	region, _ := getFirstLabel(node, "topology.kubernetes.io/region", "failure-domain.beta.kubernetes.io/region")
+	zone, _ := getFirstLabel(node, "topology.kubernetes.io/zone", "failure-domain.beta.kubernetes.io/zone")
+	os, ok := getFirstLabel(node, "kubernetes.io/os", "beta.kubernetes.io/os")
+	if !ok {
+		os = node.Status.NodeInfo.OperatingSystem
+	}
+
+	return entities.NodeInfo{
+		Provider:         p.provider,
+		Region:           region,
+		Zone:             zone,
+		OS:               os,
+		OSImage:          node.Status.NodeInfo.OSImage,
+		Architecture:     node.Status.NodeInfo.Architecture,
+		Kernel:           node.Status.NodeInfo.KernelVersion,
+		Kubelet:          node.Status.NodeInfo.KubeletVersion,
+		ContainerRuntime: node.Status.NodeInfo.ContainerRuntimeVersion,
+	}
+}
+
+func (p nodeProfile) getCapacity(node *coreV1.Node) entities.NodeCapacity {
+	return entities.NodeCapacity{
+		CPU:    node.Status.Capacity.Cpu().MilliValue(),
+		Memory: node.Status.Capacity.Memory().Value(),
+		Pods:   node.Status.Capacity.Pods().Value(),
+	}
+}
+
+func getFirstLabel(node *coreV1.Node, keys ...string) (string, bool) {
+	for _, key := range keys {
+		if value, ok := node.GetLabels()[key]; ok && value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
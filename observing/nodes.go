@@ -10,21 +10,29 @@ import (
 	"dolittle.io/fleet-observer/storage"
 	"github.com/rs/zerolog"
 	coreV1 "k8s.io/api/core/v1"
+	listersCoreV1 "k8s.io/client-go/listers/core/v1"
+	"time"
 )
 
 type NodesHandler struct {
-	nodes  storage.Nodes
-	logger zerolog.Logger
+	cluster        string
+	nodes          storage.Nodes
+	configurations storage.Configurations
+	configmaps     listersCoreV1.ConfigMapLister
+	logger         zerolog.Logger
 }
 
-func NewNodesHandler(nodes storage.Nodes, logger zerolog.Logger) *NodesHandler {
+func NewNodesHandler(cluster string, nodes storage.Nodes, configurations storage.Configurations, configmaps listersCoreV1.ConfigMapLister, logger zerolog.Logger) *NodesHandler {
 	return &NodesHandler{
-		nodes:  nodes,
-		logger: logger.With().Str("handler", "nodes").Logger(),
+		cluster:        cluster,
+		nodes:          nodes,
+		configurations: configurations,
+		configmaps:     configmaps,
+		logger:         logger.With().Str("handler", "nodes").Logger(),
 	}
 }
 
-func (nh *NodesHandler) Handle(obj any, _deleted bool) error {
+func (nh *NodesHandler) Handle(obj any, deleted bool) error {
 	knode, ok := obj.(*coreV1.Node)
 	if !ok {
 		return ReceivedWrongType(obj, "Node")
@@ -32,29 +40,183 @@ func (nh *NodesHandler) Handle(obj any, _deleted bool) error {
 
 	logger := nh.logger.With().Str("node", knode.GetName()).Logger()
 
-	hostname, ok := knode.GetLabels()["kubernetes.io/hostname"]
-	if !ok {
-		logger.Trace().Msg("Skipping node because it does not have an hostname annotation")
-		return nil
-	}
+	profile := getNodeProfile(knode)
+	poolName, hasPool := profile.getPoolName(knode)
 
-	image, ok := knode.GetLabels()["kubernetes.azure.com/node-image-version"]
-	if !ok {
-		logger.Trace().Msg("Skipping node because it does not have an node-image-version annotation")
-		return nil
+	if hasPool {
+		if err := nh.handleNodePool(knode, profile, poolName, logger); err != nil {
+			return err
+		}
 	}
 
-	nodetype, ok := knode.GetLabels()["node.kubernetes.io/instance-type"]
-	if !ok {
-		logger.Trace().Msg("Skipping node because it does not have an instance-type annotation")
-		return nil
+	node := entities.NewNode(
+		nh.cluster,
+		knode.GetName(),
+		profile.getHostname(knode),
+		profile.getImage(knode),
+		profile.getInstanceType(knode),
+		profile.getInfo(knode),
+		profile.getCapacity(knode),
+		poolName,
+	)
+	now := time.Now().UTC()
+	if err := nh.handleImageUpgrade(node, now, logger); err != nil {
+		return err
 	}
 
-	node := entities.NewNode(knode.GetName(), hostname, image, nodetype)
 	if err := nh.nodes.Set(node); err != nil {
 		return err
 	}
 	logger.Debug().Interface("node", node).Msg("Updated node")
 
+	if err := nh.handleNodeConfiguration(knode, node, deleted, now, logger); err != nil {
+		return err
+	}
+
+	return nh.handleNodeStates(knode, deleted, now, logger)
+}
+
+// handleNodeConfiguration sets the current configuration of the node as observed from when it was first seen, and the previous configurations as
+// observed until now. The first configuration of a node is observed from when the node was created, so that the pods that were scheduled
+// before the observer started are linked to it. The current configuration is observed until now when the node is deleted.
+func (nh *NodesHandler) handleNodeConfiguration(knode *coreV1.Node, node entities.Node, deleted bool, now time.Time, logger zerolog.Logger) error {
+	config, err := getNodeConfiguration(node)
+	if err != nil {
+		return err
+	}
+
+	stored, err := nh.configurations.ListNodesOf(node.UID)
+	if err != nil {
+		return err
+	}
+
+	config.Properties.ObservedFrom = now
+	if len(stored) == 0 {
+		config.Properties.ObservedFrom = knode.GetCreationTimestamp().UTC()
+	}
+
+	var previous []entities.NodeConfiguration
+	for _, other := range stored {
+		if other.UID == config.UID {
+			// configurations stored before the observed times were recorded are observed from when the node was created
+			config.Properties.ObservedFrom = knode.GetCreationTimestamp().UTC()
+			if !other.Properties.ObservedFrom.IsZero() {
+				config.Properties.ObservedFrom = other.Properties.ObservedFrom
+			}
+			continue
+		}
+		if other.Properties.ObservedTo == nil {
+			other.Properties.ObservedTo = &now
+			previous = append(previous, other)
+		}
+	}
+	if deleted {
+		config.Properties.ObservedTo = &now
+	}
+
+	if len(previous) > 0 {
+		if err := nh.configurations.SetManyNodes(previous); err != nil {
+			return err
+		}
+		logger.Debug().Int("configs", len(previous)).Msg("Updated previous node configurations")
+	}
+
+	if err := nh.configurations.SetNode(config); err != nil {
+		return err
+	}
+	logger.Debug().Interface("config", config).Msg("Updated node configuration")
+	return nil
+}
+
+func (nh *NodesHandler) handleNodePool(knode *coreV1.Node, profile nodeProfile, poolName string, logger zerolog.Logger) error {
+	minSize, maxSize, err := getNodePoolSize(nh.configmaps, poolName)
+	if err != nil {
+		return err
+	}
+
+	pool := entities.NewNodePool(
+		nh.cluster,
+		poolName,
+		profile.provider,
+		profile.getPoolMode(knode),
+		profile.getInstanceType(knode),
+		minSize,
+		maxSize,
+	)
+	if err := nh.nodes.SetPool(pool); err != nil {
+		return err
+	}
+	logger.Debug().Interface("pool", pool).Msg("Updated node pool")
+
+	return nil
+}
+
+func (nh *NodesHandler) handleImageUpgrade(node entities.Node, now time.Time, logger zerolog.Logger) error {
+	previous, exists, err := nh.nodes.Get(node.UID)
+	if err != nil {
+		return err
+	}
+	if !exists || previous.Properties.Image == "" || previous.Properties.Image == node.Properties.Image {
+		return nil
+	}
+
+	event := entities.NewNodeImageUpgradedEvent(node.UID, previous.Properties.Image, node.Properties.Image, now)
+	if err := nh.nodes.SetEvent(event); err != nil {
+		return err
+	}
+	logger.Debug().Interface("event", event).Msg("Updated node event")
+
+	return nil
+}
+
+func (nh *NodesHandler) handleNodeStates(knode *coreV1.Node, deleted bool, now time.Time, logger zerolog.Logger) error {
+	nodeUID := entities.NewNodeUID(nh.cluster, knode.GetName())
+	ongoing, err := nh.nodes.ListOngoingEvents(nodeUID)
+	if err != nil {
+		return err
+	}
+
+	ongoingByType := map[string]entities.NodeEvent{}
+	for _, event := range ongoing {
+		ongoingByType[event.Type] = event
+	}
+
+	for eventType, state := range getNodeStates(knode, now) {
+		event, isOngoing := ongoingByType[eventType]
+		delete(ongoingByType, eventType)
+
+		switch {
+		case state.active && !deleted && !isOngoing:
+			event = entities.NewNodeEvent(nodeUID, eventType, state.since, nil, state.reason)
+		case !state.active && isOngoing:
+			ended := state.since
+			if ended.Before(event.Properties.Started) {
+				ended = now
+			}
+			event.Properties.Ended = &ended
+		case deleted && isOngoing:
+			event.Properties.Ended = &now
+		default:
+			continue
+		}
+
+		if err := nh.nodes.SetEvent(event); err != nil {
+			return err
+		}
+		logger.Debug().Interface("event", event).Msg("Updated node event")
+	}
+
+	for _, event := range ongoingByType {
+		if !deleted {
+			continue
+		}
+
+		event.Properties.Ended = &now
+		if err := nh.nodes.SetEvent(event); err != nil {
+			return err
+		}
+		logger.Debug().Interface("event", event).Msg("Updated node event")
+	}
+
 	return nil
 }
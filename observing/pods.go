@@ -11,31 +11,43 @@ import (
 	"dolittle.io/fleet-observer/storage"
 	"github.com/rs/zerolog"
 	appsV1 "k8s.io/api/apps/v1"
+	batchV1 "k8s.io/api/batch/v1"
 	coreV1 "k8s.io/api/core/v1"
 	listersAppsV1 "k8s.io/client-go/listers/apps/v1"
+	listersBatchV1 "k8s.io/client-go/listers/batch/v1"
 	listersCoreV1 "k8s.io/client-go/listers/core/v1"
 	"strings"
 	"time"
 )
 
 type PodsHandler struct {
+	cluster        string
+	artifacts      storage.Artifacts
 	configurations storage.Configurations
 	deployments    storage.Deployments
 	events         storage.Events
 	configmaps     listersCoreV1.ConfigMapLister
 	secrets        listersCoreV1.SecretLister
 	replicasets    listersAppsV1.ReplicaSetLister
+	jobs           listersBatchV1.JobLister
+	cronjobs       listersBatchV1.CronJobLister
+	digester       kubernetes.SecretDigester
 	logger         zerolog.Logger
 }
 
-func NewPodsHandler(configurations storage.Configurations, deployments storage.Deployments, events storage.Events, configmaps listersCoreV1.ConfigMapLister, secrets listersCoreV1.SecretLister, replicasets listersAppsV1.ReplicaSetLister, logger zerolog.Logger) *PodsHandler {
+func NewPodsHandler(cluster string, artifacts storage.Artifacts, configurations storage.Configurations, deployments storage.Deployments, events storage.Events, configmaps listersCoreV1.ConfigMapLister, secrets listersCoreV1.SecretLister, replicasets listersAppsV1.ReplicaSetLister, jobs listersBatchV1.JobLister, cronjobs listersBatchV1.CronJobLister, digester kubernetes.SecretDigester, logger zerolog.Logger) *PodsHandler {
 	return &PodsHandler{
+		cluster:        cluster,
+		artifacts:      artifacts,
 		configurations: configurations,
 		deployments:    deployments,
 		events:         events,
 		configmaps:     configmaps,
 		secrets:        secrets,
 		replicasets:    replicasets,
+		jobs:           jobs,
+		cronjobs:       cronjobs,
+		digester:       digester,
 		logger:         logger,
 	}
 }
@@ -113,9 +125,13 @@ func (ph *PodsHandler) Handle(obj any, deleted bool) error {
 	if err != nil {
 		return err
 	}
-	envSecret, err := ph.secrets.Secrets(pod.GetNamespace()).Get(envSecName)
-	if err != nil {
-		return err
+	// the secret is left out of the configuration hash when the observer is not allowed to read secrets
+	var envSecret *coreV1.Secret
+	if ph.secrets != nil {
+		envSecret, err = ph.secrets.Secrets(pod.GetNamespace()).Get(envSecName)
+		if err != nil {
+			return err
+		}
 	}
 
 	runtimeConfigHasher := kubernetes.NewConfigHasher()
@@ -125,17 +141,24 @@ func (ph *PodsHandler) Handle(obj any, deleted bool) error {
 	customerConfigHasher := kubernetes.NewConfigHasher()
 	customerConfigHasher.WriteConfigMap(filesConfig)
 	customerConfigHasher.WriteConfigMap(envConfig)
-	customerConfigHasher.WriteSecret(envSecret)
+	customerConfigSecrets := entities.ConfigurationSecretsExcluded
+	if envSecret != nil {
+		customerConfigSecrets = entities.ConfigurationSecretsIncluded
+		customerConfigHasher.WriteSecret(envSecret, ph.digester)
+		if kubernetes.IsRedactedSecret(envSecret) && !kubernetes.HasSecretDigests(envSecret) {
+			customerConfigSecrets = entities.ConfigurationSecretsRedacted
+		}
+	}
 
-	replicaset, err := GetPodOwner(pod, ph.replicasets)
+	owner, ok, err := GetPodInstanceOwner(pod, ph.replicasets, ph.jobs, ph.cronjobs)
 	if err != nil {
 		return err
 	}
-	revision, ok := replicaset.GetAnnotations()["deployment.kubernetes.io/revision"]
 	if !ok {
 		logger.Trace().Msg("Skipping pod because the replicaset does not have a revision annotation")
 		return nil
 	}
+	deploymentID, instanceID, template, job := owner.DeploymentID, owner.InstanceID, owner.Template, owner.Job
 
 	runtimeConfig := entities.NewRuntimeConfiguration(
 		tenantID,
@@ -155,25 +178,36 @@ func (ph *PodsHandler) Handle(obj any, deleted bool) error {
 		environmentName,
 		microserviceID,
 		customerConfigHasher.GetComputedHash(),
+		customerConfigSecrets,
 	)
 	if err := ph.configurations.SetArtifact(customerConfig); err != nil {
 		return err
 	}
 	logger.Debug().Interface("config", customerConfig).Msg("Updated customer configuration")
 
-	instanceID := entities.NewDeploymentInstanceUID(
+	instanceUID := entities.NewDeploymentInstanceUID(
 		tenantID,
 		applicationID,
 		environmentName,
-		revision,
-		string(pod.GetUID()),
+		deploymentID,
+		instanceID,
 	)
 
 	var stoppedTime *time.Time
-	if instance, exists, err := ph.deployments.GetInstance(instanceID); err != nil {
+	var nodeConfigID entities.NodeConfigurationUID
+	stored, exists, err := ph.deployments.GetInstance(instanceUID)
+	if err != nil {
 		return err
-	} else if exists {
-		stoppedTime = instance.Properties.Stopped
+	}
+	if exists {
+		stoppedTime = stored.Properties.Stopped
+		nodeConfigID = stored.Links.RanOnNodeConfigurationUID
+	}
+	if nodeConfigID == "" && pod.Spec.NodeName != "" {
+		nodeConfigID, err = ph.getNodeConfigurationID(pod.Spec.NodeName, getPodScheduled(pod))
+		if err != nil {
+			return err
+		}
 	}
 	if stoppedTime == nil && deleted {
 		now := time.Now().UTC()
@@ -184,20 +218,102 @@ func (ph *PodsHandler) Handle(obj any, deleted bool) error {
 		tenantID,
 		applicationID,
 		environmentName,
-		revision,
-		string(pod.GetUID()),
+		deploymentID,
+		instanceID,
 		pod.GetCreationTimestamp().UTC(),
 		stoppedTime,
+		string(pod.Status.QOSClass),
+		getContainerImageDigest(pod, "head"),
+		getContainerImageDigest(pod, "runtime"),
 		customerConfig,
 		runtimeConfig,
-		pod.Spec.NodeName,
+		entities.NewNodeUID(ph.cluster, pod.Spec.NodeName),
+		nodeConfigID,
 	)
+	if job != nil {
+		if exists {
+			keepFirstJobPod(&instance, *stored, ph.cluster)
+		}
+		setJobStatus(&instance, job, false)
+	}
 	if err := ph.deployments.SetInstance(instance); err != nil {
 		return err
 	}
 	logger.Debug().Interface("instance", instance).Msg("Updated deployment instance")
 
-	return ph.handlePodRestarts(instanceID, pod, logger)
+	if err := ph.handleArtifactDigest(instance, template, tenantID, microserviceID, pod, logger); err != nil {
+		return err
+	}
+
+	if err := ph.handlePodRestarts(instanceUID, pod, logger); err != nil {
+		return err
+	}
+
+	return ph.handleContainerTerminations(instanceUID, pod, logger)
+}
+
+// getNodeConfigurationID returns the configuration the node had when the pod was scheduled, or an empty ID if it has not been observed yet.
+// The configuration is linked when the pod is handled again after the node has been observed.
+func (ph *PodsHandler) getNodeConfigurationID(nodeName string, scheduled time.Time) (entities.NodeConfigurationUID, error) {
+	configs, err := ph.configurations.ListNodesOf(entities.NewNodeUID(ph.cluster, nodeName))
+	if err != nil {
+		return "", err
+	}
+
+	config, ok := getNodeConfigurationAt(configs, scheduled)
+	if !ok {
+		return "", nil
+	}
+	return config.UID, nil
+}
+
+// getPodScheduled returns the time the pod was scheduled on its node, or when it was created if the time is not known
+func getPodScheduled(pod *coreV1.Pod) time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == coreV1.PodScheduled && condition.Status == coreV1.ConditionTrue && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime.UTC()
+		}
+	}
+	return pod.GetCreationTimestamp().UTC()
+}
+
+func (ph *PodsHandler) handleArtifactDigest(instance entities.DeploymentInstance, template coreV1.PodSpec, tenantID, microserviceID string, pod *coreV1.Pod, logger zerolog.Logger) error {
+	digest := instance.Properties.ArtifactDigest
+	if digest == "" {
+		return nil
+	}
+
+	_, headContainer, ok := getRuntimeAndHeadContainer(template)
+	if !ok {
+		return nil
+	}
+
+	versionID := entities.NewArtifactVersionUID(tenantID, microserviceID, getArtifactVersionName(headContainer))
+	previous, added, err := ph.artifacts.AddVersionDigest(versionID, digest)
+	if err != nil {
+		return err
+	}
+	if !added {
+		logger.Trace().Msg("Skipping artifact digest because it is already known or the artifact version does not exist yet")
+		return nil
+	}
+	logger.Debug().Str("version", string(versionID)).Str("digest", digest).Msg("Added artifact version digest")
+
+	if len(previous) > 0 {
+		event := entities.NewArtifactTagMovedEvent(
+			string(pod.GetUID()),
+			headContainer.Name,
+			digest,
+			instance.Properties.Started,
+			instance.UID,
+		)
+		if err := ph.events.Set(event); err != nil {
+			return err
+		}
+		logger.Warn().Str("version", string(versionID)).Str("digest", digest).Strs("previous", previous).Msg("Artifact version tag has moved to a different image digest")
+	}
+
+	return nil
 }
 
 func (ph *PodsHandler) handlePodRestarts(id entities.DeploymentInstanceUID, pod *coreV1.Pod, logger zerolog.Logger) error {
@@ -260,6 +376,34 @@ func (ph *PodsHandler) getRestartsEventFor(id entities.DeploymentInstanceUID, po
 	return event
 }
 
+func (ph *PodsHandler) handleContainerTerminations(id entities.DeploymentInstanceUID, pod *coreV1.Pod, logger zerolog.Logger) error {
+	for _, status := range pod.Status.ContainerStatuses {
+		for _, terminated := range []*coreV1.ContainerStateTerminated{status.LastTerminationState.Terminated, status.State.Terminated} {
+			if terminated == nil || terminated.FinishedAt.IsZero() {
+				continue
+			}
+
+			event := entities.NewContainerTerminatedEvent(
+				string(pod.GetUID()),
+				status.Name,
+				terminated.StartedAt.UTC(),
+				terminated.FinishedAt.UTC(),
+				terminated.Reason,
+				int(terminated.ExitCode),
+				int(terminated.Signal),
+				status.Name == "runtime",
+				id,
+			)
+			if err := ph.events.Set(event); err != nil {
+				return err
+			}
+			logger.Debug().Interface("event", event).Msg("Updated event")
+		}
+	}
+
+	return nil
+}
+
 func (ph *PodsHandler) updateRestartEvent(event entities.Event) error {
 	oldEvent, exists, err := ph.events.Get(event.UID)
 	if err != nil {
@@ -278,6 +422,66 @@ func (ph *PodsHandler) updateRestartEvent(event entities.Event) error {
 	return ph.events.Set(event)
 }
 
+// PodInstanceOwner is the workload that a pod is an instance of
+type PodInstanceOwner struct {
+	// DeploymentID is the revision of the ReplicaSet, or the version of the Job
+	DeploymentID string
+	// InstanceID is the UID of the pod for ReplicaSets, and the UID of the Job for all the pods of a Job
+	InstanceID string
+	Template   coreV1.PodSpec
+	// Job is the Job that owns the pod, or nil if the pod is owned by a ReplicaSet
+	Job *batchV1.Job
+}
+
+// GetPodInstanceOwner finds the ReplicaSet or Job that owns the pod, and returns false if the ReplicaSet does not have a revision.
+// Every pod of a ReplicaSet is a separate instance, while all the pods of a Job are recorded as a single instance of the Job,
+// so that retried pods of the same run are recorded together and events of any of them are linked to the same instance.
+func GetPodInstanceOwner(pod *coreV1.Pod, replicasets listersAppsV1.ReplicaSetLister, jobs listersBatchV1.JobLister, cronjobs listersBatchV1.CronJobLister) (PodInstanceOwner, bool, error) {
+	if replicaset, err := GetPodOwner(pod, replicasets); err == nil {
+		revision, ok := replicaset.GetAnnotations()["deployment.kubernetes.io/revision"]
+		if !ok {
+			return PodInstanceOwner{}, false, nil
+		}
+		return PodInstanceOwner{
+			DeploymentID: revision,
+			InstanceID:   string(pod.GetUID()),
+			Template:     replicaset.Spec.Template.Spec,
+		}, true, nil
+	}
+
+	job, err := GetPodJob(pod, jobs)
+	if err != nil {
+		return PodInstanceOwner{}, false, err
+	}
+	_, deploymentID, err := GetJobDefinition(job, cronjobs)
+	if err != nil {
+		return PodInstanceOwner{}, false, err
+	}
+	return PodInstanceOwner{
+		DeploymentID: deploymentID,
+		InstanceID:   string(job.GetUID()),
+		Template:     job.Spec.Template.Spec,
+		Job:          job,
+	}, true, nil
+}
+
+// keepFirstJobPod keeps the pod specific values of the instance of a Job that were recorded from the first pod of the Job,
+// so that they do not change every time another pod of the Job is handled. Values that were not known yet are set from the current pod.
+func keepFirstJobPod(instance *entities.DeploymentInstance, stored entities.DeploymentInstance, cluster string) {
+	if stored.Properties.QOSClass != "" {
+		instance.Properties.QOSClass = stored.Properties.QOSClass
+	}
+	if stored.Properties.ArtifactDigest != "" {
+		instance.Properties.ArtifactDigest = stored.Properties.ArtifactDigest
+	}
+	if stored.Properties.RuntimeDigest != "" {
+		instance.Properties.RuntimeDigest = stored.Properties.RuntimeDigest
+	}
+	if stored.Links.ScheduledOnNodeUID != "" && stored.Links.ScheduledOnNodeUID != entities.NewNodeUID(cluster, "") {
+		instance.Links.ScheduledOnNodeUID = stored.Links.ScheduledOnNodeUID
+	}
+}
+
 func GetPodOwner(pod *coreV1.Pod, replicasets listersAppsV1.ReplicaSetLister) (*appsV1.ReplicaSet, error) {
 	for _, owner := range pod.GetOwnerReferences() {
 		if owner.Kind == "ReplicaSet" {
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/registry"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var DefaultRuntimeImagePatterns = []string{"dolittle/runtime"}
+
+// RuntimeVersionParser parses entities.RuntimeVersion from runtime container images whose repository
+// (with or without the registry host) matches one of the configured path.Match patterns.
+// If the versions resolver is set, the version of images referenced by digest without a version tag is resolved from the version label of the image.
+type RuntimeVersionParser struct {
+	patterns []string
+	versions registry.VersionResolver
+}
+
+func NewRuntimeVersionParser(patterns []string, versions registry.VersionResolver) RuntimeVersionParser {
+	if len(patterns) == 0 {
+		patterns = DefaultRuntimeImagePatterns
+	}
+	return RuntimeVersionParser{
+		patterns: patterns,
+		versions: versions,
+	}
+}
+
+var semanticVersionExpression = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+func (p RuntimeVersionParser) Parse(image string) (entities.RuntimeVersion, error) {
+	reference, err := registry.ParseImageReference(image)
+	if err != nil || !p.matches(reference) {
+		return entities.RuntimeVersion{}, FailedToParseRuntimeVersion(image)
+	}
+
+	if version, ok := parseRuntimeVersion(reference.Tag); ok {
+		return version, nil
+	}
+
+	// the labels of images referenced by digest cannot change, so they identify the version when the tag does not
+	if reference.Digest == "" || p.versions == nil {
+		return entities.RuntimeVersion{}, FailedToParseRuntimeVersion(image)
+	}
+
+	label, found, err := p.versions.ResolveVersion(image)
+	if err != nil {
+		return entities.RuntimeVersion{}, FailedToResolveRuntimeVersion(image, err)
+	}
+	if version, ok := parseRuntimeVersion(label); found && ok {
+		return version, nil
+	}
+	return entities.RuntimeVersion{}, FailedToParseRuntimeVersion(image)
+}
+
+func parseRuntimeVersion(tag string) (entities.RuntimeVersion, bool) {
+	// Image tags cannot contain '+', so build metadata is commonly separated by '_' instead
+	matches := semanticVersionExpression.FindStringSubmatch(strings.Replace(tag, "_", "+", 1))
+	if len(matches) < 6 {
+		return entities.RuntimeVersion{}, false
+	}
+
+	var numbers [3]int
+	for i := range numbers {
+		number, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return entities.RuntimeVersion{}, false
+		}
+		numbers[i] = number
+	}
+
+	return entities.NewRuntimeVersion(
+		numbers[0],
+		numbers[1],
+		numbers[2],
+		matches[4],
+		matches[5],
+		nil,
+	), true
+}
+
+func (p RuntimeVersionParser) matches(reference registry.ImageReference) bool {
+	for _, pattern := range p.patterns {
+		for _, name := range []string{reference.Name(), reference.Registry + "/" + reference.Name()} {
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
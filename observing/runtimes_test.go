@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"errors"
+	"testing"
+)
+
+// labelledVersions is a VersionResolver that returns the version labels of the images in the map
+type labelledVersions map[string]string
+
+func (l labelledVersions) ResolveVersion(image string) (string, bool, error) {
+	if image == "dolittle/runtime@sha256:unreachable" {
+		return "", false, errors.New("registry is unreachable")
+	}
+	version, found := l[image]
+	return version, found, nil
+}
+
+func TestParseRuntimeVersion(t *testing.T) {
+	versions := labelledVersions{
+		"dolittle/runtime@sha256:labelled":      "8.3.0",
+		"dolittle/runtime@sha256:prerelease":    "v9.0.0-alpha.1",
+		"dolittle/runtime@sha256:not-a-version": "latest",
+	}
+
+	tests := []struct {
+		name       string
+		patterns   []string
+		image      string
+		expected   string
+		unresolved bool
+	}{
+		{name: "tag", image: "dolittle/runtime:8.3.0", expected: "8.3.0"},
+		{name: "tag with v prefix", image: "dolittle/runtime:v8.3.0", expected: "8.3.0"},
+		{name: "prerelease", image: "dolittle/runtime:8.3.0-beta.2", expected: "8.3.0-beta.2"},
+		{name: "build metadata separated by underscore", image: "dolittle/runtime:8.3.0_build.5", expected: "8.3.0+build.5"},
+		{name: "docker hub registry host", image: "docker.io/dolittle/runtime:8.3.0", expected: "8.3.0"},
+		{name: "tag and digest", image: "dolittle/runtime:8.3.0@sha256:other", expected: "8.3.0"},
+		{name: "glob pattern with registry host", patterns: []string{"*.azurecr.io/dolittle/*"}, image: "dolittle.azurecr.io/dolittle/runtime:7.1.0", expected: "7.1.0"},
+		{name: "glob pattern without registry host", patterns: []string{"dolittle/run*"}, image: "registry.local:5000/dolittle/runtime:7.1.0", expected: "7.1.0"},
+		{name: "other repository", image: "dolittle/other:8.3.0", unresolved: true},
+		{name: "pattern does not match", patterns: []string{"*.azurecr.io/dolittle/*"}, image: "dolittle/runtime:8.3.0", unresolved: true},
+		{name: "tag is not a version", image: "dolittle/runtime:latest", unresolved: true},
+		{name: "tag is a partial version", image: "dolittle/runtime:8.3", unresolved: true},
+		{name: "no tag", image: "dolittle/runtime", unresolved: true},
+		{name: "digest with version label", image: "dolittle/runtime@sha256:labelled", expected: "8.3.0"},
+		{name: "digest with prerelease version label", image: "dolittle/runtime@sha256:prerelease", expected: "9.0.0-alpha.1"},
+		{name: "digest without version label", image: "dolittle/runtime@sha256:unlabelled", unresolved: true},
+		{name: "digest with label that is not a version", image: "dolittle/runtime@sha256:not-a-version", unresolved: true},
+		{name: "digest that cannot be resolved", image: "dolittle/runtime@sha256:unreachable", unresolved: true},
+		{name: "invalid reference", image: "dolittle/runtime:", unresolved: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser := NewRuntimeVersionParser(test.patterns, versions)
+
+			version, err := parser.Parse(test.image)
+
+			if test.unresolved {
+				if !errors.Is(err, CouldNotParseRuntimeVersion) {
+					t.Errorf("expected %v, got version %v and error %v", CouldNotParseRuntimeVersion, version.UID, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(version.UID) != test.expected {
+				t.Errorf("expected version %v, got %v", test.expected, version.UID)
+			}
+		})
+	}
+}
+
+func TestParseRuntimeVersionWithoutVersionResolver(t *testing.T) {
+	parser := NewRuntimeVersionParser(nil, nil)
+
+	if _, err := parser.Parse("dolittle/runtime@sha256:labelled"); !errors.Is(err, CouldNotParseRuntimeVersion) {
+		t.Errorf("expected %v, got %v", CouldNotParseRuntimeVersion, err)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package observing
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+	"fmt"
+	"github.com/rs/zerolog"
+	coreV1 "k8s.io/api/core/v1"
+	"strings"
+)
+
+// internalLoadBalancerAnnotations are the provider specific annotations that make a LoadBalancer service only reachable from the private network
+var internalLoadBalancerAnnotations = []string{
+	"service.beta.kubernetes.io/azure-load-balancer-internal",
+	"service.beta.kubernetes.io/aws-load-balancer-internal",
+	"networking.gke.io/load-balancer-type",
+}
+
+type ServicesHandler struct {
+	endpoints storage.Endpoints
+	logger    zerolog.Logger
+}
+
+func NewServicesHandler(endpoints storage.Endpoints, logger zerolog.Logger) *ServicesHandler {
+	return &ServicesHandler{
+		endpoints: endpoints,
+		logger:    logger.With().Str("handler", "services").Logger(),
+	}
+}
+
+func (sh *ServicesHandler) Handle(obj any, deleted bool) error {
+	service, ok := obj.(*coreV1.Service)
+	if !ok {
+		return ReceivedWrongType(obj, "Service")
+	}
+
+	logger := sh.logger.With().Str("namespace", service.GetNamespace()).Str("name", service.GetName()).Logger()
+
+	tenantID, applicationID, environmentName, ok := GetEnvironmentIdentifiers(service.ObjectMeta)
+	if !ok {
+		logger.Trace().Msg("Skipping service because it is missing environment identifiers")
+		return nil
+	}
+
+	microserviceID := service.GetAnnotations()["dolittle.io/microservice-id"]
+	host := fmt.Sprintf("%v.%v.svc", service.GetName(), service.GetNamespace())
+	public := isPublicService(service)
+
+	var endpoints []entities.Endpoint
+	for _, port := range service.Spec.Ports {
+		endpoint := entities.NewEndpoint(
+			tenantID,
+			applicationID,
+			environmentName,
+			entities.ServiceEndpointKind,
+			service.GetName(),
+			host,
+			"",
+			int(port.Port),
+			port.Port == 443 || strings.EqualFold(port.Name, "https"),
+			&public,
+			microserviceID,
+		)
+		endpoints = append(endpoints, endpoint)
+	}
+
+	environment := entities.NewEnvironmentUID(tenantID, applicationID, environmentName)
+	return setExposedEndpoints(sh.endpoints, environment, entities.ServiceEndpointKind, service.GetName(), endpoints, deleted, logger)
+}
+
+func isPublicService(service *coreV1.Service) bool {
+	if service.Spec.Type != coreV1.ServiceTypeLoadBalancer {
+		return false
+	}
+	for _, annotation := range internalLoadBalancerAnnotations {
+		if value, ok := service.GetAnnotations()[annotation]; ok && (value == "true" || strings.EqualFold(value, "internal")) {
+			return false
+		}
+	}
+	return true
+}
@@ -8,19 +8,24 @@ package observing
 import (
 	"context"
 	"dolittle.io/fleet-observer/kubernetes"
+	"dolittle.io/fleet-observer/registry"
 	"dolittle.io/fleet-observer/storage"
 	"github.com/rs/zerolog"
-	"k8s.io/client-go/informers"
+	listersAutoscalingV2 "k8s.io/client-go/listers/autoscaling/v2"
+	listersCoreV1 "k8s.io/client-go/listers/core/v1"
 )
 
-func StartAllObservers(factory informers.SharedInformerFactory, repositories *storage.Repositories, logger zerolog.Logger, ctx context.Context) {
+func StartAllObservers(cluster string, factories *kubernetes.Factories, repositories *storage.Repositories, parser RuntimeVersionParser, releases registry.ReleaseDateResolver, digester kubernetes.SecretDigester, logger zerolog.Logger, ctx context.Context) []*kubernetes.Observer {
 	stop := ctx.Done()
 
 	nodesHandler := NewNodesHandler(
+		cluster,
 		repositories.Nodes,
+		repositories.Configurations,
+		factories.System.Core().V1().ConfigMaps().Lister(),
 		logger,
 	)
-	nodes := kubernetes.NewObserver("nodes", factory.Core().V1().Nodes().Informer(), logger)
+	nodes := kubernetes.NewObserver("nodes", factories.Cluster.Core().V1().Nodes().Informer(), logger)
 	nodes.Start(nodesHandler, stop)
 
 	namespacesHandler := NewNamespacesHandler(
@@ -28,26 +33,70 @@ func StartAllObservers(factory informers.SharedInformerFactory, repositories *st
 		repositories.Applications,
 		logger,
 	)
-	namespaces := kubernetes.NewObserver("namespaces", factory.Core().V1().Namespaces().Informer(), logger)
+	namespaces := kubernetes.NewObserver("namespaces", factories.Cluster.Core().V1().Namespaces().Informer(), logger)
 	namespaces.Start(namespacesHandler, stop)
 
+	observers := []*kubernetes.Observer{nodes, namespaces}
+	for _, factory := range factories.Namespaced {
+		observers = append(observers, startNamespacedObservers(cluster, factory, repositories, parser, releases, digester, logger, stop)...)
+	}
+	return observers
+}
+
+func startNamespacedObservers(cluster string, factory kubernetes.NamespacedFactory, repositories *storage.Repositories, parser RuntimeVersionParser, releases registry.ReleaseDateResolver, digester kubernetes.SecretDigester, logger zerolog.Logger, stop <-chan struct{}) []*kubernetes.Observer {
+	var secrets listersCoreV1.SecretLister
+	if factory.SecretsAllowed {
+		secrets = factory.Unfiltered.Core().V1().Secrets().Lister()
+	} else {
+		logger.Warn().Str("namespace", factory.Namespace).Msg("Not allowed to list and watch Secrets, configuration hashes will not include Secrets and are marked as excluded")
+	}
+
+	var hpas listersAutoscalingV2.HorizontalPodAutoscalerLister
+	if factory.AutoscalersServed {
+		hpas = factory.Autoscaling().V2().HorizontalPodAutoscalers().Lister()
+	} else {
+		logger.Warn().Str("namespace", factory.Namespace).Msg("The cluster does not serve autoscaling/v2 HorizontalPodAutoscalers, autoscalers and scaled events will not be observed")
+	}
+
 	replicasetsHandler := NewReplicasetHandler(
+		cluster,
 		repositories.Environments,
 		repositories.Artifacts,
 		repositories.Runtimes,
 		repositories.Deployments,
+		parser,
+		releases,
 		logger,
 	)
 	replicasets := kubernetes.NewObserver("replicasets", factory.Apps().V1().ReplicaSets().Informer(), logger)
 	replicasets.Start(replicasetsHandler, stop)
 
+	jobsHandler := NewJobsHandler(
+		cluster,
+		repositories.Environments,
+		repositories.Artifacts,
+		repositories.Runtimes,
+		repositories.Deployments,
+		parser,
+		releases,
+		factory.Unfiltered.Batch().V1().CronJobs().Lister(),
+		logger,
+	)
+	jobs := kubernetes.NewObserver("jobs", factory.Batch().V1().Jobs().Informer(), logger)
+	jobs.Start(jobsHandler, stop)
+
 	podsHandler := NewPodsHandler(
+		cluster,
+		repositories.Artifacts,
 		repositories.Configurations,
 		repositories.Deployments,
 		repositories.Events,
-		factory.Core().V1().ConfigMaps().Lister(),
-		factory.Core().V1().Secrets().Lister(),
+		factory.Unfiltered.Core().V1().ConfigMaps().Lister(),
+		secrets,
 		factory.Apps().V1().ReplicaSets().Lister(),
+		factory.Batch().V1().Jobs().Lister(),
+		factory.Unfiltered.Batch().V1().CronJobs().Lister(),
+		digester,
 		logger,
 	)
 	pods := kubernetes.NewObserver("pods", factory.Core().V1().Pods().Informer(), logger)
@@ -55,10 +104,46 @@ func StartAllObservers(factory informers.SharedInformerFactory, repositories *st
 
 	eventsHandler := NewEventsHandler(
 		repositories.Events,
+		repositories.Autoscalers,
 		factory.Core().V1().Pods().Lister(),
 		factory.Apps().V1().ReplicaSets().Lister(),
+		factory.Batch().V1().Jobs().Lister(),
+		factory.Unfiltered.Batch().V1().CronJobs().Lister(),
+		hpas,
+		factory.Unfiltered.Apps().V1().Deployments().Lister(),
 		logger,
 	)
-	events := kubernetes.NewObserver("events", factory.Core().V1().Events().Informer(), logger)
+	events := kubernetes.NewObserver("events", factory.Unfiltered.Core().V1().Events().Informer(), logger)
 	events.Start(eventsHandler, stop)
+
+	servicesHandler := NewServicesHandler(
+		repositories.Endpoints,
+		logger,
+	)
+	services := kubernetes.NewObserver("services", factory.Core().V1().Services().Informer(), logger)
+	services.Start(servicesHandler, stop)
+
+	ingressesHandler := NewIngressesHandler(
+		repositories.Endpoints,
+		factory.Core().V1().Services().Lister(),
+		logger,
+	)
+	ingresses := kubernetes.NewObserver("ingresses", factory.Networking().V1().Ingresses().Informer(), logger)
+	ingresses.Start(ingressesHandler, stop)
+
+	observers := []*kubernetes.Observer{replicasets, jobs, pods, events, services, ingresses}
+	if !factory.AutoscalersServed {
+		return observers
+	}
+
+	autoscalersHandler := NewAutoscalersHandler(
+		repositories.Autoscalers,
+		factory.Unfiltered.Apps().V1().Deployments().Lister(),
+		factory.Apps().V1().ReplicaSets().Lister(),
+		logger,
+	)
+	autoscalers := kubernetes.NewObserver("autoscalers", factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer(), logger)
+	autoscalers.Start(autoscalersHandler, stop)
+
+	return append(observers, autoscalers)
 }
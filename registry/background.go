@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package registry
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"sync"
+	"time"
+)
+
+// BackgroundResolver is an AsyncReleaseDateResolver that returns the release dates cached by a CachedResolver without waiting for the registry.
+// Images that are not cached, or where the cached release date has expired, are resolved one at a time in the background.
+type BackgroundResolver struct {
+	cached *CachedResolver
+	logger zerolog.Logger
+
+	lock    sync.Mutex
+	queue   []string
+	waiting map[string][]func(time.Time)
+	wake    chan struct{}
+}
+
+// NewBackgroundResolver creates a new BackgroundResolver that resolves images in the background until the context is cancelled
+func NewBackgroundResolver(cached *CachedResolver, logger zerolog.Logger, ctx context.Context) *BackgroundResolver {
+	r := &BackgroundResolver{
+		cached:  cached,
+		logger:  logger.With().Str("component", "registry").Logger(),
+		waiting: map[string][]func(time.Time){},
+		wake:    make(chan struct{}, 1),
+	}
+	go r.run(ctx)
+	return r
+}
+
+func (r *BackgroundResolver) ResolveReleaseDate(image string) (time.Time, bool, error) {
+	return r.ResolveReleaseDateAsync(image, nil)
+}
+
+func (r *BackgroundResolver) ResolveReleaseDateAsync(image string, resolved func(time.Time)) (time.Time, bool, error) {
+	cached, current, err := r.cached.getCached(image)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if !current {
+		r.enqueue(image, resolved)
+	}
+	if cached != nil && cached.Properties.Released != nil {
+		return *cached.Properties.Released, true, nil
+	}
+	return time.Time{}, false, nil
+}
+
+func (r *BackgroundResolver) enqueue(image string, resolved func(time.Time)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	callbacks, queued := r.waiting[image]
+	if !queued {
+		r.queue = append(r.queue, image)
+	}
+	if resolved != nil {
+		callbacks = append(callbacks, resolved)
+	}
+	r.waiting[image] = callbacks
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (r *BackgroundResolver) next() (string, []func(time.Time), bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.queue) == 0 {
+		return "", nil, false
+	}
+	image := r.queue[0]
+	r.queue = r.queue[1:]
+	callbacks := r.waiting[image]
+	delete(r.waiting, image)
+	return image, callbacks, true
+}
+
+func (r *BackgroundResolver) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		image, callbacks, ok := r.next()
+		if !ok {
+			select {
+			case <-r.wake:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		released, found, err := r.cached.ResolveReleaseDate(image)
+		if err != nil {
+			r.logger.Warn().Err(err).Str("image", image).Msg("Failed to resolve image release date")
+			continue
+		}
+		if !found {
+			continue
+		}
+		for _, resolved := range callbacks {
+			resolved(released)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package registry
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+	"time"
+)
+
+// CachedResolver is a ReleaseDateResolver that caches the results of another ReleaseDateResolver in storage.
+// Release dates of images referenced by digest are cached indefinitely, while release dates of images referenced by tag
+// are resolved again after the configured expiry, since the tag can be moved to another image. Failed lookups are retried after the configured interval.
+type CachedResolver struct {
+	resolver ReleaseDateResolver
+	images   storage.Images
+	retry    time.Duration
+	expiry   time.Duration
+}
+
+func NewCachedResolver(resolver ReleaseDateResolver, images storage.Images, retry, expiry time.Duration) *CachedResolver {
+	return &CachedResolver{
+		resolver: resolver,
+		images:   images,
+		retry:    retry,
+		expiry:   expiry,
+	}
+}
+
+func (c *CachedResolver) ResolveReleaseDate(image string) (time.Time, bool, error) {
+	cached, current, err := c.getCached(image)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if current && cached.Properties.Released != nil {
+		return *cached.Properties.Released, true, nil
+	}
+	if current {
+		return time.Time{}, false, nil
+	}
+
+	return c.refresh(image, cached)
+}
+
+// getCached returns the cached release of the image if there is one, and whether it is still current or should be resolved again
+func (c *CachedResolver) getCached(image string) (*entities.ImageRelease, bool, error) {
+	cached, exists, err := c.images.GetRelease(entities.NewImageReleaseUID(image))
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	if cached.Properties.Released == nil {
+		return cached, time.Since(cached.Properties.Resolved) < c.retry, nil
+	}
+	if reference, err := ParseImageReference(image); err == nil && reference.Digest != "" {
+		return cached, true, nil
+	}
+	return cached, time.Since(cached.Properties.Resolved) < c.expiry, nil
+}
+
+// refresh resolves the release date of the image and stores the result. If the release date of an image that was resolved before
+// cannot be resolved because of an error, the previous release date is kept until the image is resolved again.
+func (c *CachedResolver) refresh(image string, cached *entities.ImageRelease) (time.Time, bool, error) {
+	released, found, resolveErr := c.resolver.ResolveReleaseDate(image)
+
+	release := entities.NewImageRelease(image, nil, time.Now().UTC())
+	if resolveErr == nil && found {
+		release.Properties.Released = &released
+	}
+	if resolveErr != nil && cached != nil && cached.Properties.Released != nil {
+		release.Properties.Released = cached.Properties.Released
+		released, found, resolveErr = *cached.Properties.Released, true, nil
+	}
+	if err := c.images.SetRelease(release); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return released, found, resolveErr
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package registry
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"github.com/rs/zerolog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type storedImages struct {
+	lock     sync.Mutex
+	releases map[entities.ImageReleaseUID]entities.ImageRelease
+}
+
+func (s *storedImages) SetRelease(release entities.ImageRelease) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.releases[release.UID] = release
+	return nil
+}
+
+func (s *storedImages) SetManyReleases(releases []entities.ImageRelease) error {
+	for _, release := range releases {
+		s.SetRelease(release)
+	}
+	return nil
+}
+
+func (s *storedImages) GetRelease(id entities.ImageReleaseUID) (*entities.ImageRelease, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	release, ok := s.releases[id]
+	return &release, ok, nil
+}
+
+// countingResolver is a ReleaseDateResolver that counts the lookups, and waits for the release channel before each lookup if it is set
+type countingResolver struct {
+	released time.Time
+	release  chan struct{}
+	lock     sync.Mutex
+	lookups  int
+}
+
+func (r *countingResolver) ResolveReleaseDate(string) (time.Time, bool, error) {
+	if r.release != nil {
+		<-r.release
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.lookups++
+	return r.released, true, nil
+}
+
+func (r *countingResolver) count() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.lookups
+}
+
+func newStoredImages() *storedImages {
+	return &storedImages{releases: map[entities.ImageReleaseUID]entities.ImageRelease{}}
+}
+
+func (s *storedImages) resolvedAt(image string, released, resolved time.Time) {
+	s.SetRelease(entities.NewImageRelease(image, &released, resolved))
+}
+
+func TestTagReleaseDatesAreResolvedAgainWhenExpired(t *testing.T) {
+	images := newStoredImages()
+	moved := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	images.resolvedAt("dolittle/runtime:8.0.0", moved.Add(-24*time.Hour), time.Now().Add(-2*time.Hour))
+	resolver := &countingResolver{released: moved}
+
+	released, found, err := NewCachedResolver(resolver, images, time.Hour, time.Hour).ResolveReleaseDate("dolittle/runtime:8.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolver.count() != 1 || !found || !released.Equal(moved) {
+		t.Errorf("expected the expired release date to be resolved again as %v, got %v after %d lookups", moved, released, resolver.count())
+	}
+}
+
+func TestTagReleaseDatesAreCachedUntilExpired(t *testing.T) {
+	images := newStoredImages()
+	cached := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	images.resolvedAt("dolittle/runtime:8.0.0", cached, time.Now().Add(-time.Minute))
+	resolver := &countingResolver{released: cached.Add(time.Hour)}
+
+	released, found, err := NewCachedResolver(resolver, images, time.Hour, time.Hour).ResolveReleaseDate("dolittle/runtime:8.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolver.count() != 0 || !found || !released.Equal(cached) {
+		t.Errorf("expected the cached release date %v, got %v after %d lookups", cached, released, resolver.count())
+	}
+}
+
+func TestDigestReleaseDatesDoNotExpire(t *testing.T) {
+	images := newStoredImages()
+	image := "dolittle/runtime:8.0.0@sha256:0123456789abcdef"
+	cached := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	images.resolvedAt(image, cached, time.Now().Add(-48*time.Hour))
+	resolver := &countingResolver{released: cached.Add(time.Hour)}
+
+	released, found, err := NewCachedResolver(resolver, images, time.Hour, time.Hour).ResolveReleaseDate(image)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolver.count() != 0 || !found || !released.Equal(cached) {
+		t.Errorf("expected the cached release date %v, got %v after %d lookups", cached, released, resolver.count())
+	}
+}
+
+func TestBackgroundResolverDoesNotWaitForRegistry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	images := newStoredImages()
+	created := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	resolver := &countingResolver{released: created, release: make(chan struct{})}
+	background := NewBackgroundResolver(NewCachedResolver(resolver, images, time.Hour, time.Hour), zerolog.Nop(), ctx)
+
+	resolved := make(chan time.Time, 2)
+	for i := 0; i < 2; i++ {
+		_, found, err := background.ResolveReleaseDateAsync("dolittle/runtime:8.0.0", func(released time.Time) { resolved <- released })
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found {
+			t.Fatalf("expected the release date not to be known before it is resolved")
+		}
+	}
+
+	close(resolver.release)
+	for i := 0; i < 2; i++ {
+		select {
+		case released := <-resolved:
+			if !released.Equal(created) {
+				t.Errorf("expected the resolved release date %v, got %v", created, released)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the release date to be resolved")
+		}
+	}
+	if resolver.count() != 1 {
+		t.Errorf("expected the image to be looked up once, got %d lookups", resolver.count())
+	}
+
+	released, found, err := background.ResolveReleaseDate("dolittle/runtime:8.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || !released.Equal(created) {
+		t.Errorf("expected the cached release date %v, got %v (found %v)", created, released, found)
+	}
+}
@@ -0,0 +1,315 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/knadh/koanf"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+)
+
+type Credentials struct {
+	Username string
+	Password string
+}
+
+type manifest struct {
+	MediaType string `json:"mediaType"`
+	Config    struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Platform struct {
+			OS           string `json:"os"`
+			Architecture string `json:"architecture"`
+		} `json:"platform"`
+	} `json:"manifests"`
+}
+
+type imageConfig struct {
+	Created *time.Time `json:"created"`
+	Config  struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"config"`
+}
+
+// VersionLabel is the image label that holds the version of the packaged software
+const VersionLabel = "org.opencontainers.image.version"
+
+// DistributionResolver is a ReleaseDateResolver that reads the image creation time from the image configuration
+// using the OCI distribution (Docker Registry HTTP API V2) protocol
+type DistributionResolver struct {
+	client       *http.Client
+	credentials  map[string]Credentials
+	insecure     map[string]bool
+	os           string
+	architecture string
+	tokens       map[string]string
+	versions     map[string]versionLabel
+	lock         sync.Mutex
+}
+
+type versionLabel struct {
+	version string
+	found   bool
+}
+
+// NewDistributionResolverUsing creates a new DistributionResolver using 'registry.credentials', 'registry.insecure',
+// 'registry.platform' and 'registry.timeout' from the provided config
+func NewDistributionResolverUsing(config *koanf.Koanf) *DistributionResolver {
+	credentials := map[string]Credentials{}
+	for _, entry := range config.Slices("registry.credentials") {
+		credentials[entry.String("host")] = Credentials{
+			Username: entry.String("username"),
+			Password: entry.String("password"),
+		}
+	}
+
+	insecure := map[string]bool{}
+	for _, host := range config.Strings("registry.insecure") {
+		insecure[host] = true
+	}
+
+	os, architecture := "linux", "amd64"
+	if platform := strings.SplitN(config.String("registry.platform"), "/", 2); len(platform) == 2 {
+		os, architecture = platform[0], platform[1]
+	}
+
+	return &DistributionResolver{
+		client:       &http.Client{Timeout: config.Duration("registry.timeout")},
+		credentials:  credentials,
+		insecure:     insecure,
+		os:           os,
+		architecture: architecture,
+		tokens:       map[string]string{},
+		versions:     map[string]versionLabel{},
+	}
+}
+
+func (r *DistributionResolver) ResolveReleaseDate(image string) (time.Time, bool, error) {
+	reference, err := ParseImageReference(image)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	config, found, err := r.getImageConfig(reference)
+	if err != nil || !found || config.Created == nil {
+		return time.Time{}, false, err
+	}
+
+	return config.Created.UTC(), true, nil
+}
+
+// ResolveVersion returns the version label of the image, and false if the image does not have one.
+// The labels of images referenced by digest cannot change, so they are only read from the registry once.
+func (r *DistributionResolver) ResolveVersion(image string) (string, bool, error) {
+	reference, err := ParseImageReference(image)
+	if err != nil {
+		return "", false, err
+	}
+
+	r.lock.Lock()
+	label, ok := r.versions[image]
+	r.lock.Unlock()
+	if ok {
+		return label.version, label.found, nil
+	}
+
+	config, found, err := r.getImageConfig(reference)
+	if err != nil {
+		return "", false, err
+	}
+	label.version, label.found = config.Config.Labels[VersionLabel]
+	label.found = found && label.found
+
+	if reference.Digest != "" {
+		r.lock.Lock()
+		r.versions[image] = label
+		r.lock.Unlock()
+	}
+	return label.version, label.found, nil
+}
+
+func (r *DistributionResolver) getImageConfig(reference ImageReference) (imageConfig, bool, error) {
+	found, configDigest, err := r.getConfigDigest(reference, reference.Identifier())
+	if err != nil || !found {
+		return imageConfig{}, false, err
+	}
+
+	config := imageConfig{}
+	found, err = r.get(reference, "blobs/"+configDigest, nil, &config)
+	return config, found, err
+}
+
+func (r *DistributionResolver) getConfigDigest(reference ImageReference, identifier string) (bool, string, error) {
+	result := manifest{}
+	found, err := r.get(
+		reference,
+		"manifests/"+identifier,
+		[]string{mediaTypeOCIIndex, mediaTypeOCIManifest, mediaTypeDockerManifestList, mediaTypeDockerManifest},
+		&result)
+	if err != nil || !found {
+		return false, "", err
+	}
+
+	if result.MediaType != mediaTypeOCIIndex && result.MediaType != mediaTypeDockerManifestList && len(result.Manifests) == 0 {
+		return result.Config.Digest != "", result.Config.Digest, nil
+	}
+
+	for _, platformManifest := range result.Manifests {
+		if platformManifest.Platform.OS == r.os && platformManifest.Platform.Architecture == r.architecture {
+			return r.getConfigDigest(reference, platformManifest.Digest)
+		}
+	}
+
+	return false, "", fmt.Errorf("%w: %v/%v in %v", ErrNoMatchingPlatformManifest, r.os, r.architecture, reference)
+}
+
+func (r *DistributionResolver) get(reference ImageReference, path string, accept []string, v any) (bool, error) {
+	scheme := "https"
+	if r.insecure[reference.Registry] {
+		scheme = "http"
+	}
+	target := fmt.Sprintf("%v://%v/v2/%v/%v", scheme, reference.endpoint(), reference.Repository, path)
+
+	response, err := r.do(reference, target, accept)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusUnauthorized {
+		if err := r.authenticate(reference, response.Header.Get("WWW-Authenticate")); err != nil {
+			return false, err
+		}
+
+		response.Body.Close()
+		response, err = r.do(reference, target, accept)
+		if err != nil {
+			return false, err
+		}
+		defer response.Body.Close()
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return true, json.NewDecoder(response.Body).Decode(v)
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, UnexpectedRegistryResponse(target, response.StatusCode)
+	}
+}
+
+func (r *DistributionResolver) do(reference ImageReference, target string, accept []string) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mediaType := range accept {
+		request.Header.Add("Accept", mediaType)
+	}
+
+	r.lock.Lock()
+	authorization, ok := r.tokens[reference.Registry+"/"+reference.Repository]
+	r.lock.Unlock()
+	if ok {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	return r.client.Do(request)
+}
+
+var challengeParameterExpression = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+func (r *DistributionResolver) authenticate(reference ImageReference, challenge string) error {
+	credentials, hasCredentials := r.credentials[reference.Registry]
+
+	scheme := strings.ToLower(strings.SplitN(challenge, " ", 2)[0])
+	switch scheme {
+	case "basic":
+		if !hasCredentials {
+			return UnexpectedRegistryResponse(reference.String(), http.StatusUnauthorized)
+		}
+		request, _ := http.NewRequest(http.MethodGet, "/", nil)
+		request.SetBasicAuth(credentials.Username, credentials.Password)
+		r.setAuthorization(reference, request.Header.Get("Authorization"))
+		return nil
+	case "bearer":
+	default:
+		return UnsupportedAuthenticationScheme(challenge)
+	}
+
+	parameters := map[string]string{}
+	for _, match := range challengeParameterExpression.FindAllStringSubmatch(challenge, -1) {
+		parameters[match[1]] = match[2]
+	}
+
+	realm, err := url.Parse(parameters["realm"])
+	if err != nil || parameters["realm"] == "" {
+		return UnsupportedAuthenticationScheme(challenge)
+	}
+
+	query := realm.Query()
+	if service, ok := parameters["service"]; ok {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%v:pull", reference.Repository))
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if hasCredentials {
+		request.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+
+	response, err := r.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return UnexpectedRegistryResponse(realm.String(), response.StatusCode)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return err
+	}
+
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	r.setAuthorization(reference, "Bearer "+token.Token)
+	return nil
+}
+
+func (r *DistributionResolver) setAuthorization(reference ImageReference, authorization string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.tokens[reference.Registry+"/"+reference.Repository] = authorization
+}
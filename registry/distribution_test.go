@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/knadh/koanf"
+	"github.com/knadh/koanf/providers/confmap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// standInRegistry is a local container registry that serves manifests and blobs over the OCI distribution protocol,
+// and optionally requires a bearer token issued by its own token endpoint
+type standInRegistry struct {
+	test      *httptest.Server
+	manifests map[string]any
+	blobs     map[string]any
+	username  string
+	password  string
+}
+
+func newStandInRegistry(t *testing.T) *standInRegistry {
+	t.Helper()
+
+	r := &standInRegistry{
+		manifests: map[string]any{},
+		blobs:     map[string]any{},
+	}
+	r.test = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.test.Close)
+	return r
+}
+
+func (r *standInRegistry) host() string {
+	return strings.TrimPrefix(r.test.URL, "http://")
+}
+
+func (r *standInRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if username, password, ok := req.BasicAuth(); !ok || username != r.username || password != r.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": "stand-in-token"})
+		return
+	}
+
+	if r.username != "" && req.Header.Get("Authorization") != "Bearer stand-in-token" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%v/token",service="stand-in"`, r.test.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	content, ok := r.manifests[req.URL.Path]
+	if !ok {
+		content, ok = r.blobs[req.URL.Path]
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(content)
+}
+
+func (r *standInRegistry) addImage(repository, tag, configDigest string, created time.Time) {
+	r.manifests[fmt.Sprintf("/v2/%v/manifests/%v", repository, tag)] = map[string]any{
+		"mediaType": mediaTypeOCIManifest,
+		"config":    map[string]any{"digest": configDigest},
+	}
+	r.blobs[fmt.Sprintf("/v2/%v/blobs/%v", repository, configDigest)] = map[string]any{"created": created}
+}
+
+func (r *standInRegistry) addIndex(repository, tag string, platforms map[string]string) {
+	var manifests []map[string]any
+	for platform, digest := range platforms {
+		parts := strings.SplitN(platform, "/", 2)
+		manifests = append(manifests, map[string]any{
+			"digest":   digest,
+			"platform": map[string]any{"os": parts[0], "architecture": parts[1]},
+		})
+	}
+	r.manifests[fmt.Sprintf("/v2/%v/manifests/%v", repository, tag)] = map[string]any{
+		"mediaType": mediaTypeOCIIndex,
+		"manifests": manifests,
+	}
+}
+
+func (r *standInRegistry) resolver(t *testing.T) *DistributionResolver {
+	t.Helper()
+
+	config := koanf.New(".")
+	values := map[string]any{
+		"registry.insecure": []string{r.host()},
+		"registry.platform": "linux/amd64",
+		"registry.timeout":  "5s",
+	}
+	if r.username != "" {
+		values["registry.credentials"] = []any{map[string]any{"host": r.host(), "username": r.username, "password": r.password}}
+	}
+	if err := config.Load(confmap.Provider(values, "."), nil); err != nil {
+		t.Fatal(err)
+	}
+	return NewDistributionResolverUsing(config)
+}
+
+func TestResolvesReleaseDateFromImageConfig(t *testing.T) {
+	registry := newStandInRegistry(t)
+	created := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	registry.addImage("dolittle/runtime", "8.0.0", "sha256:config", created)
+
+	released, found, err := registry.resolver(t).ResolveReleaseDate(registry.host() + "/dolittle/runtime:8.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || !released.Equal(created) {
+		t.Errorf("expected release date %v, got %v (found %v)", created, released, found)
+	}
+}
+
+func TestResolvesReleaseDateOfPlatformImageFromIndex(t *testing.T) {
+	registry := newStandInRegistry(t)
+	created := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	registry.addIndex("dolittle/runtime", "8.0.0", map[string]string{
+		"linux/arm64": "sha256:arm64",
+		"linux/amd64": "sha256:amd64",
+	})
+	registry.addImage("dolittle/runtime", "sha256:arm64", "sha256:arm64-config", created.Add(time.Hour))
+	registry.addImage("dolittle/runtime", "sha256:amd64", "sha256:amd64-config", created)
+
+	released, found, err := registry.resolver(t).ResolveReleaseDate(registry.host() + "/dolittle/runtime:8.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || !released.Equal(created) {
+		t.Errorf("expected release date %v of the linux/amd64 image, got %v (found %v)", created, released, found)
+	}
+}
+
+func TestAuthenticatesWithBearerTokenFromRealm(t *testing.T) {
+	registry := newStandInRegistry(t)
+	registry.username, registry.password = "observer", "secret"
+	created := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	registry.addImage("dolittle/runtime", "8.0.0", "sha256:config", created)
+
+	released, found, err := registry.resolver(t).ResolveReleaseDate(registry.host() + "/dolittle/runtime:8.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || !released.Equal(created) {
+		t.Errorf("expected release date %v, got %v (found %v)", created, released, found)
+	}
+}
+
+func TestMissingTagIsNotFound(t *testing.T) {
+	registry := newStandInRegistry(t)
+
+	_, found, err := registry.resolver(t).ResolveReleaseDate(registry.host() + "/dolittle/runtime:8.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Errorf("expected the release date of a missing tag not to be found")
+	}
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidImageReference           = errors.New("invalid image reference")
+	ErrUnexpectedRegistryResponse      = errors.New("unexpected response from registry")
+	ErrUnsupportedAuthenticationScheme = errors.New("unsupported registry authentication scheme")
+	ErrNoMatchingPlatformManifest      = errors.New("no manifest found for the expected platform")
+)
+
+func InvalidImageReference(image string) error {
+	return fmt.Errorf("%w: %v", ErrInvalidImageReference, image)
+}
+
+func UnexpectedRegistryResponse(url string, status int) error {
+	return fmt.Errorf("%w: %v returned status %v", ErrUnexpectedRegistryResponse, url, status)
+}
+
+func UnsupportedAuthenticationScheme(challenge string) error {
+	return fmt.Errorf("%w: %v", ErrUnsupportedAuthenticationScheme, challenge)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package registry
+
+import (
+	"strings"
+)
+
+const (
+	DockerHubRegistry = "docker.io"
+	dockerHubEndpoint = "registry-1.docker.io"
+)
+
+// ImageReference is a parsed container image reference on the form [registry/]repository[:tag][@digest]
+type ImageReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseImageReference parses a container image reference, normalising Docker Hub references
+// to the same registry and repository names as the Docker CLI would use
+func ParseImageReference(image string) (ImageReference, error) {
+	reference := ImageReference{}
+	remainder := image
+
+	if index := strings.Index(remainder, "@"); index >= 0 {
+		reference.Digest = remainder[index+1:]
+		remainder = remainder[:index]
+		if !strings.Contains(reference.Digest, ":") {
+			return ImageReference{}, InvalidImageReference(image)
+		}
+	}
+
+	if index := strings.LastIndex(remainder, ":"); index > strings.LastIndex(remainder, "/") {
+		reference.Tag = remainder[index+1:]
+		remainder = remainder[:index]
+	}
+
+	reference.Registry = DockerHubRegistry
+	if index := strings.Index(remainder, "/"); index >= 0 {
+		first := remainder[:index]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			reference.Registry = first
+			remainder = remainder[index+1:]
+		}
+	}
+
+	if remainder == "" || strings.HasSuffix(image, ":") {
+		return ImageReference{}, InvalidImageReference(image)
+	}
+
+	if reference.Registry == DockerHubRegistry && !strings.Contains(remainder, "/") {
+		remainder = "library/" + remainder
+	}
+	reference.Repository = remainder
+
+	if reference.Tag == "" && reference.Digest == "" {
+		reference.Tag = "latest"
+	}
+
+	return reference, nil
+}
+
+// Name returns the repository name without the registry, and without the implicit 'library/' prefix for Docker Hub
+func (r ImageReference) Name() string {
+	if r.Registry == DockerHubRegistry {
+		return strings.TrimPrefix(r.Repository, "library/")
+	}
+	return r.Repository
+}
+
+// Identifier returns the digest if the reference has one, or the tag otherwise
+func (r ImageReference) Identifier() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+func (r ImageReference) String() string {
+	str := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		str += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		str += "@" + r.Digest
+	}
+	return str
+}
+
+func (r ImageReference) endpoint() string {
+	if r.Registry == DockerHubRegistry {
+		return dockerHubEndpoint
+	}
+	return r.Registry
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package registry
+
+import "time"
+
+// ReleaseDateResolver resolves the time a container image was created
+type ReleaseDateResolver interface {
+	// ResolveReleaseDate returns the creation time of the image, and false if it could not be determined
+	ResolveReleaseDate(image string) (time.Time, bool, error)
+}
+
+// AsyncReleaseDateResolver is a ReleaseDateResolver that can resolve release dates without blocking the caller
+type AsyncReleaseDateResolver interface {
+	ReleaseDateResolver
+	// ResolveReleaseDateAsync returns the known release date of the image without waiting for it to be resolved, and false if it is not known yet.
+	// If the release date is not known, or should be resolved again, it is resolved later and passed to resolved if it is found.
+	ResolveReleaseDateAsync(image string, resolved func(time.Time)) (time.Time, bool, error)
+}
+
+// VersionResolver resolves the version of a container image from its labels
+type VersionResolver interface {
+	// ResolveVersion returns the version label of the image, and false if the image does not have one
+	ResolveVersion(image string) (string, bool, error)
+}
+
+// NoReleaseDateResolver is a ReleaseDateResolver that never resolves any release dates
+type NoReleaseDateResolver struct{}
+
+func (NoReleaseDateResolver) ResolveReleaseDate(_ string) (time.Time, bool, error) {
+	return time.Time{}, false, nil
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package sampling
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"math"
+	"sort"
+)
+
+func aggregate(samples []int64) entities.UsageAggregate {
+	if len(samples) == 0 {
+		return entities.UsageAggregate{}
+	}
+
+	sorted := make([]int64, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum int64
+	for _, sample := range sorted {
+		sum += sample
+	}
+
+	return entities.UsageAggregate{
+		Min: sorted[0],
+		Avg: sum / int64(len(sorted)),
+		Max: sorted[len(sorted)-1],
+		P95: percentile(sorted, 0.95),
+	}
+}
+
+func percentile(sorted []int64, p float64) int64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package sampling
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/kubernetes"
+	"dolittle.io/fleet-observer/storage"
+	"errors"
+	"github.com/rs/zerolog"
+	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
+	"time"
+)
+
+var ErrNonPositiveDuration = errors.New("the sampling period and window must be greater than zero")
+
+func StartUsageSampling(period, window time.Duration, client metrics.Interface, factories *kubernetes.Factories, repositories *storage.Repositories, logger zerolog.Logger, ctx context.Context) error {
+	if period <= 0 || window <= 0 {
+		return ErrNonPositiveDuration
+	}
+
+	usageLogger := logger.With().Str("sampler", "usage").Logger()
+	for _, factory := range factories.Namespaced {
+		usage := NewUsage(
+			window,
+			factory.Namespace,
+			client,
+			factory.Core().V1().Pods().Lister(),
+			factory.Apps().V1().ReplicaSets().Lister(),
+			repositories.Usages,
+			usageLogger,
+		)
+		go RunSampler(usage, period, factories, usageLogger, ctx)
+	}
+	return nil
+}
+
+type Sampler interface {
+	Sample(ctx context.Context) error
+}
+
+func RunSampler(sampler Sampler, period time.Duration, factories *kubernetes.Factories, logger zerolog.Logger, ctx context.Context) {
+	timer := time.NewTimer(period)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Debug().Msg("Stopping sampling")
+			return
+		case <-timer.C:
+		}
+
+		factories.WaitForCacheSync(ctx.Done())
+
+		logger.Debug().Msg("Running sampling")
+
+		err := sampler.Sample(ctx)
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			logger.Debug().Msg("Stopping sampling")
+			return
+		}
+
+		if err != nil {
+			logger.Error().Err(err).Msg("Sampling failed")
+		}
+
+		timer.Reset(period)
+	}
+}
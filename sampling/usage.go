@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package sampling
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/observing"
+	"dolittle.io/fleet-observer/storage"
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listersAppsV1 "k8s.io/client-go/listers/apps/v1"
+	listersCoreV1 "k8s.io/client-go/listers/core/v1"
+	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
+	"time"
+)
+
+type usageKey struct {
+	instance  entities.DeploymentInstanceUID
+	container string
+}
+
+type usageWindow struct {
+	from   time.Time
+	last   time.Time
+	cpu    []int64
+	memory []int64
+}
+
+// Usage samples the resource usage of the containers of deployment instances, and aggregates the samples in windows of a fixed duration.
+// The windows are only kept in memory, so the windows that started before the sampler are skipped to not overwrite the stored aggregates with partial ones.
+type Usage struct {
+	window      time.Duration
+	started     time.Time
+	namespace   string
+	client      metrics.Interface
+	pods        listersCoreV1.PodLister
+	replicasets listersAppsV1.ReplicaSetLister
+	usages      storage.Usages
+	windows     map[usageKey]*usageWindow
+	logger      zerolog.Logger
+}
+
+func NewUsage(window time.Duration, namespace string, client metrics.Interface, pods listersCoreV1.PodLister, replicasets listersAppsV1.ReplicaSetLister, usages storage.Usages, logger zerolog.Logger) *Usage {
+	return &Usage{
+		window:      window,
+		started:     time.Now().UTC(),
+		namespace:   namespace,
+		client:      client,
+		pods:        pods,
+		replicasets: replicasets,
+		usages:      usages,
+		windows:     map[usageKey]*usageWindow{},
+		logger:      logger,
+	}
+}
+
+func (u *Usage) Sample(ctx context.Context) error {
+	podMetricses, err := u.client.MetricsV1beta1().PodMetricses(u.namespace).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, podMetrics := range podMetricses.Items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		logger := u.logger.With().Str("namespace", podMetrics.GetNamespace()).Str("name", podMetrics.GetName()).Logger()
+
+		pod, err := u.pods.Pods(podMetrics.GetNamespace()).Get(podMetrics.GetName())
+		if err != nil && errors.IsNotFound(err) {
+			logger.Trace().Msg("Skipping pod metrics because the pod no longer exists")
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		instance, ok := observing.GetDeploymentInstanceUID(pod, u.replicasets)
+		if !ok {
+			logger.Trace().Msg("Skipping pod metrics because the pod is not a deployment instance")
+			continue
+		}
+
+		timestamp := podMetrics.Timestamp.UTC()
+		for _, container := range podMetrics.Containers {
+			usage, changed := u.record(
+				usageKey{instance, container.Name},
+				timestamp,
+				container.Usage.Cpu().MilliValue(),
+				container.Usage.Memory().Value(),
+			)
+			if !changed {
+				continue
+			}
+
+			if err := u.usages.Set(usage); err != nil {
+				return err
+			}
+			logger.Trace().Interface("usage", usage).Msg("Updated resource usage")
+		}
+	}
+
+	u.forgetWindowsBefore(time.Now().UTC().Add(-u.window))
+	return nil
+}
+
+func (u *Usage) record(key usageKey, timestamp time.Time, cpu, memory int64) (entities.ResourceUsage, bool) {
+	from := timestamp.Truncate(u.window)
+	if from.Before(u.started) {
+		return entities.ResourceUsage{}, false
+	}
+
+	window, exists := u.windows[key]
+	if !exists || !window.from.Equal(from) {
+		window = &usageWindow{from: from}
+		u.windows[key] = window
+	} else if !timestamp.After(window.last) {
+		return entities.ResourceUsage{}, false
+	}
+
+	window.last = timestamp
+	window.cpu = append(window.cpu, cpu)
+	window.memory = append(window.memory, memory)
+
+	return entities.NewResourceUsage(
+		key.instance,
+		key.container,
+		window.from,
+		window.last,
+		len(window.cpu),
+		aggregate(window.cpu),
+		aggregate(window.memory),
+	), true
+}
+
+func (u *Usage) forgetWindowsBefore(before time.Time) {
+	for key, window := range u.windows {
+		if window.last.Before(before) {
+			delete(u.windows, key)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package sampling
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"github.com/rs/zerolog"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	listersAppsV1 "k8s.io/client-go/listers/apps/v1"
+	listersCoreV1 "k8s.io/client-go/listers/core/v1"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	metricsV1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"k8s.io/metrics/pkg/client/clientset/versioned/fake"
+	"testing"
+	"time"
+)
+
+type recordedUsages struct {
+	set []entities.ResourceUsage
+}
+
+func (r *recordedUsages) Set(usage entities.ResourceUsage) error {
+	r.set = append(r.set, usage)
+	return nil
+}
+
+func (r *recordedUsages) SetMany(usages []entities.ResourceUsage) error {
+	r.set = append(r.set, usages...)
+	return nil
+}
+
+func (r *recordedUsages) List() ([]entities.ResourceUsage, error) {
+	return r.set, nil
+}
+
+// usageFixture is a Usage sampler for a single deployment instance, with the pod metrics returned by a fake metrics client
+type usageFixture struct {
+	usage   *Usage
+	usages  *recordedUsages
+	metrics []metricsV1beta1.PodMetrics
+}
+
+func newUsageFixture(t *testing.T, window time.Duration) *usageFixture {
+	t.Helper()
+
+	replicaset := &appsV1.ReplicaSet{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "microservice-1234",
+			Namespace:   "application",
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": "3"},
+		},
+	}
+	pod := &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "microservice-1234-abcd",
+			Namespace: "application",
+			UID:       "pod-uid",
+			Labels:    map[string]string{"environment": "Dev"},
+			Annotations: map[string]string{
+				"dolittle.io/tenant-id":       "customer",
+				"dolittle.io/application-id":  "application",
+				"dolittle.io/microservice-id": "microservice",
+			},
+			OwnerReferences: []metaV1.OwnerReference{{Kind: "ReplicaSet", Name: replicaset.GetName()}},
+		},
+	}
+
+	pods := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	replicasets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := pods.Add(pod); err != nil {
+		t.Fatal(err)
+	}
+	if err := replicasets.Add(replicaset); err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := &usageFixture{usages: &recordedUsages{}}
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "pods", func(clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsV1beta1.PodMetricsList{Items: fixture.metrics}, nil
+	})
+
+	fixture.usage = NewUsage(
+		window,
+		"application",
+		client,
+		listersCoreV1.NewPodLister(pods),
+		listersAppsV1.NewReplicaSetLister(replicasets),
+		fixture.usages,
+		zerolog.Nop(),
+	)
+	return fixture
+}
+
+func (f *usageFixture) sample(t *testing.T, timestamp time.Time, cpu, memory string) {
+	t.Helper()
+
+	f.metrics = []metricsV1beta1.PodMetrics{{
+		ObjectMeta: metaV1.ObjectMeta{Name: "microservice-1234-abcd", Namespace: "application"},
+		Timestamp:  metaV1.NewTime(timestamp),
+		Containers: []metricsV1beta1.ContainerMetrics{{
+			Name: "head",
+			Usage: coreV1.ResourceList{
+				coreV1.ResourceCPU:    resource.MustParse(cpu),
+				coreV1.ResourceMemory: resource.MustParse(memory),
+			},
+		}},
+	}}
+	if err := f.usage.Sample(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSamplesInTheSameWindowAreAggregated(t *testing.T) {
+	fixture := newUsageFixture(t, time.Hour)
+	next := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+
+	fixture.sample(t, next.Add(time.Minute), "100m", "100Mi")
+	fixture.sample(t, next.Add(2*time.Minute), "300m", "200Mi")
+
+	if len(fixture.usages.set) != 2 {
+		t.Fatalf("expected 2 writes, got %d", len(fixture.usages.set))
+	}
+	usage := fixture.usages.set[1]
+	if usage.UID != fixture.usages.set[0].UID {
+		t.Errorf("expected both writes to update the same usage, got %v and %v", fixture.usages.set[0].UID, usage.UID)
+	}
+	if !usage.Properties.From.Equal(next) || !usage.Properties.To.Equal(next.Add(2*time.Minute)) {
+		t.Errorf("expected the usage to span %v to %v, got %v to %v", next, next.Add(2*time.Minute), usage.Properties.From, usage.Properties.To)
+	}
+	if usage.Properties.Samples != 2 {
+		t.Errorf("expected 2 samples, got %d", usage.Properties.Samples)
+	}
+	if usage.Properties.CPU.Min != 100 || usage.Properties.CPU.Avg != 200 || usage.Properties.CPU.Max != 300 {
+		t.Errorf("unexpected cpu aggregate %+v", usage.Properties.CPU)
+	}
+	if usage.Links.MeasuredOnDeploymentInstanceUID != entities.NewDeploymentInstanceUID("customer", "application", "Dev", "3", "pod-uid") {
+		t.Errorf("unexpected deployment instance %v", usage.Links.MeasuredOnDeploymentInstanceUID)
+	}
+}
+
+func TestSamplesThatAreNotNewerAreIgnored(t *testing.T) {
+	fixture := newUsageFixture(t, time.Hour)
+	next := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+
+	fixture.sample(t, next.Add(time.Minute), "100m", "100Mi")
+	fixture.sample(t, next.Add(time.Minute), "100m", "100Mi")
+
+	if len(fixture.usages.set) != 1 {
+		t.Fatalf("expected 1 write, got %d", len(fixture.usages.set))
+	}
+}
+
+func TestNewWindowStartsNewUsage(t *testing.T) {
+	fixture := newUsageFixture(t, time.Hour)
+	next := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+
+	fixture.sample(t, next.Add(time.Minute), "100m", "100Mi")
+	fixture.sample(t, next.Add(time.Hour+time.Minute), "300m", "200Mi")
+
+	if len(fixture.usages.set) != 2 {
+		t.Fatalf("expected 2 writes, got %d", len(fixture.usages.set))
+	}
+	if fixture.usages.set[0].UID == fixture.usages.set[1].UID {
+		t.Errorf("expected the windows to be written to different usages")
+	}
+	if fixture.usages.set[1].Properties.Samples != 1 {
+		t.Errorf("expected the new window to only have 1 sample, got %d", fixture.usages.set[1].Properties.Samples)
+	}
+}
+
+func TestWindowsThatStartedBeforeSamplingAreSkipped(t *testing.T) {
+	fixture := newUsageFixture(t, time.Hour)
+	current := time.Now().UTC().Truncate(time.Hour)
+	if current.Equal(fixture.usage.started) {
+		t.Skip("sampling started exactly at the start of a window")
+	}
+
+	fixture.sample(t, fixture.usage.started, "100m", "100Mi")
+
+	if len(fixture.usages.set) != 0 {
+		t.Fatalf("expected the partial window to be skipped, got %d writes", len(fixture.usages.set))
+	}
+}
+
+func TestStartUsageSamplingRejectsNonPositiveDurations(t *testing.T) {
+	for _, durations := range [][2]time.Duration{{0, time.Hour}, {time.Minute, 0}, {-time.Minute, time.Hour}} {
+		err := StartUsageSampling(durations[0], durations[1], fake.NewSimpleClientset(), nil, nil, zerolog.Nop(), context.Background())
+		if err != ErrNonPositiveDuration {
+			t.Errorf("expected %v for period %v and window %v, got %v", ErrNonPositiveDuration, durations[0], durations[1], err)
+		}
+	}
+}
@@ -9,6 +9,11 @@ import "dolittle.io/fleet-observer/entities"
 
 type Applications interface {
 	Set(application entities.Application) error
+	SetMany(applications []entities.Application) error
 	Get(id entities.ApplicationUID) (*entities.Application, bool, error)
 	List() ([]entities.Application, error)
+	SetName(name entities.ApplicationName) error
+	SetManyNames(names []entities.ApplicationName) error
+	GetCurrentName(id entities.ApplicationUID) (*entities.ApplicationName, bool, error)
+	ListNames() ([]entities.ApplicationName, error)
 }
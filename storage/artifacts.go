@@ -9,7 +9,14 @@ import "dolittle.io/fleet-observer/entities"
 
 type Artifacts interface {
 	Set(artifact entities.Artifact) error
+	SetMany(artifacts []entities.Artifact) error
 	List() ([]entities.Artifact, error)
+	// SetVersion sets the version, and adds its digests to the stored digests instead of replacing them
 	SetVersion(version entities.ArtifactVersion) error
+	SetManyVersions(versions []entities.ArtifactVersion) error
+	// AddVersionDigest atomically adds the digest to the stored version, and returns the previously stored digests.
+	// Returns false if the version does not exist or already has the digest.
+	AddVersionDigest(id entities.ArtifactVersionUID, digest string) ([]string, bool, error)
+	GetVersion(id entities.ArtifactVersionUID) (*entities.ArtifactVersion, bool, error)
 	ListVersions() ([]entities.ArtifactVersion, error)
 }
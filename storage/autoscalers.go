@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package storage
+
+import "dolittle.io/fleet-observer/entities"
+
+type Autoscalers interface {
+	Set(autoscaler entities.Autoscaler) error
+	SetMany(autoscalers []entities.Autoscaler) error
+	Get(id entities.AutoscalerUID) (*entities.Autoscaler, bool, error)
+	List() ([]entities.Autoscaler, error)
+	SetEvent(event entities.ScaledEvent) error
+	SetManyEvents(events []entities.ScaledEvent) error
+	GetEvent(id entities.ScaledEventUID) (*entities.ScaledEvent, bool, error)
+	ListEvents() ([]entities.ScaledEvent, error)
+}
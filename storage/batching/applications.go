@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Applications struct {
+	storage.Applications
+	applications *buffer[entities.Application, entities.ApplicationUID]
+	names        *buffer[entities.ApplicationName, entities.ApplicationNameUID]
+}
+
+func NewApplications(repository storage.Applications, batcher *Batcher) *Applications {
+	return &Applications{
+		Applications: repository,
+		applications: newBuffer(batcher, func(application entities.Application) entities.ApplicationUID {
+			return application.UID
+		}, repository.SetMany),
+		names: newBuffer(batcher, func(name entities.ApplicationName) entities.ApplicationNameUID {
+			return name.UID
+		}, repository.SetManyNames),
+	}
+}
+
+func (a *Applications) Set(application entities.Application) error {
+	return a.applications.set(application)
+}
+
+func (a *Applications) SetMany(applications []entities.Application) error {
+	return a.applications.setMany(applications)
+}
+
+func (a *Applications) SetName(name entities.ApplicationName) error {
+	return a.names.set(name)
+}
+
+func (a *Applications) SetManyNames(names []entities.ApplicationName) error {
+	return a.names.setMany(names)
+}
+
+func (a *Applications) Get(id entities.ApplicationUID) (*entities.Application, bool, error) {
+	return a.applications.get(id, a.Applications.Get)
+}
+
+func (a *Applications) List() ([]entities.Application, error) {
+	if err := a.applications.flush(); err != nil {
+		return nil, err
+	}
+	return a.Applications.List()
+}
+
+func (a *Applications) GetCurrentName(id entities.ApplicationUID) (*entities.ApplicationName, bool, error) {
+	if err := a.names.flush(); err != nil {
+		return nil, false, err
+	}
+	return a.Applications.GetCurrentName(id)
+}
+
+func (a *Applications) ListNames() ([]entities.ApplicationName, error) {
+	if err := a.names.flush(); err != nil {
+		return nil, err
+	}
+	return a.Applications.ListNames()
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Artifacts struct {
+	storage.Artifacts
+	artifacts *buffer[entities.Artifact, entities.ArtifactUID]
+	versions  *buffer[entities.ArtifactVersion, entities.ArtifactVersionUID]
+}
+
+func NewArtifacts(repository storage.Artifacts, batcher *Batcher) *Artifacts {
+	return &Artifacts{
+		Artifacts: repository,
+		artifacts: newBuffer(batcher, func(artifact entities.Artifact) entities.ArtifactUID {
+			return artifact.UID
+		}, repository.SetMany),
+		versions: newMergingBuffer(batcher, func(version entities.ArtifactVersion) entities.ArtifactVersionUID {
+			return version.UID
+		}, entities.MergeArtifactVersionDigests, repository.SetManyVersions),
+	}
+}
+
+func (a *Artifacts) Set(artifact entities.Artifact) error {
+	return a.artifacts.set(artifact)
+}
+
+func (a *Artifacts) SetMany(artifacts []entities.Artifact) error {
+	return a.artifacts.setMany(artifacts)
+}
+
+func (a *Artifacts) SetVersion(version entities.ArtifactVersion) error {
+	return a.versions.set(version)
+}
+
+func (a *Artifacts) SetManyVersions(versions []entities.ArtifactVersion) error {
+	return a.versions.setMany(versions)
+}
+
+func (a *Artifacts) List() ([]entities.Artifact, error) {
+	if err := a.artifacts.flush(); err != nil {
+		return nil, err
+	}
+	return a.Artifacts.List()
+}
+
+func (a *Artifacts) AddVersionDigest(id entities.ArtifactVersionUID, digest string) ([]string, bool, error) {
+	if err := a.versions.flush(); err != nil {
+		return nil, false, err
+	}
+	return a.Artifacts.AddVersionDigest(id, digest)
+}
+
+func (a *Artifacts) GetVersion(id entities.ArtifactVersionUID) (*entities.ArtifactVersion, bool, error) {
+	return a.versions.get(id, a.Artifacts.GetVersion)
+}
+
+func (a *Artifacts) ListVersions() ([]entities.ArtifactVersion, error) {
+	if err := a.versions.flush(); err != nil {
+		return nil, err
+	}
+	return a.Artifacts.ListVersions()
+}
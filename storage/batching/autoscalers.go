@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Autoscalers struct {
+	storage.Autoscalers
+	autoscalers *buffer[entities.Autoscaler, entities.AutoscalerUID]
+	events      *buffer[entities.ScaledEvent, entities.ScaledEventUID]
+}
+
+func NewAutoscalers(repository storage.Autoscalers, batcher *Batcher) *Autoscalers {
+	return &Autoscalers{
+		Autoscalers: repository,
+		autoscalers: newBuffer(batcher, func(autoscaler entities.Autoscaler) entities.AutoscalerUID {
+			return autoscaler.UID
+		}, repository.SetMany),
+		events: newBuffer(batcher, func(event entities.ScaledEvent) entities.ScaledEventUID {
+			return event.UID
+		}, repository.SetManyEvents),
+	}
+}
+
+func (a *Autoscalers) Set(autoscaler entities.Autoscaler) error {
+	return a.autoscalers.set(autoscaler)
+}
+
+func (a *Autoscalers) SetMany(autoscalers []entities.Autoscaler) error {
+	return a.autoscalers.setMany(autoscalers)
+}
+
+func (a *Autoscalers) SetEvent(event entities.ScaledEvent) error {
+	return a.events.set(event)
+}
+
+func (a *Autoscalers) SetManyEvents(events []entities.ScaledEvent) error {
+	return a.events.setMany(events)
+}
+
+func (a *Autoscalers) Get(id entities.AutoscalerUID) (*entities.Autoscaler, bool, error) {
+	return a.autoscalers.get(id, a.Autoscalers.Get)
+}
+
+func (a *Autoscalers) List() ([]entities.Autoscaler, error) {
+	if err := a.autoscalers.flush(); err != nil {
+		return nil, err
+	}
+	return a.Autoscalers.List()
+}
+
+func (a *Autoscalers) GetEvent(id entities.ScaledEventUID) (*entities.ScaledEvent, bool, error) {
+	return a.events.get(id, a.Autoscalers.GetEvent)
+}
+
+func (a *Autoscalers) ListEvents() ([]entities.ScaledEvent, error) {
+	if err := a.events.flush(); err != nil {
+		return nil, err
+	}
+	return a.Autoscalers.ListEvents()
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/storage"
+	"github.com/rs/zerolog"
+	"sync"
+	"time"
+)
+
+type flusher interface {
+	flush() error
+}
+
+// Batcher coalesces the writes to the repositories into batches, that are written when they are full or periodically
+type Batcher struct {
+	size    int
+	lock    sync.Mutex
+	buffers []flusher
+}
+
+// NewBatcher creates a Batcher that writes a batch when it contains size entities of the same kind
+func NewBatcher(size int) *Batcher {
+	return &Batcher{
+		size: size,
+	}
+}
+
+// Wrap creates repositories that coalesce the writes to the provided repositories into batches.
+// Entities that have not been written yet are returned by the Get methods, and the List methods write the pending batch first.
+func Wrap(repositories *storage.Repositories, batcher *Batcher) *storage.Repositories {
+	return &storage.Repositories{
+		Clusters:       NewClusters(repositories.Clusters, batcher),
+		Nodes:          NewNodes(repositories.Nodes, batcher),
+		Customers:      NewCustomers(repositories.Customers, batcher),
+		Applications:   NewApplications(repositories.Applications, batcher),
+		Environments:   NewEnvironments(repositories.Environments, batcher),
+		Artifacts:      NewArtifacts(repositories.Artifacts, batcher),
+		Runtimes:       NewRuntimes(repositories.Runtimes, batcher),
+		Deployments:    NewDeployments(repositories.Deployments, batcher),
+		Configurations: NewConfigurations(repositories.Configurations, batcher),
+		Events:         NewEvents(repositories.Events, batcher),
+		Usages:         NewUsages(repositories.Usages, batcher),
+		Images:         NewImages(repositories.Images, batcher),
+		Endpoints:      NewEndpoints(repositories.Endpoints, batcher),
+		Autoscalers:    NewAutoscalers(repositories.Autoscalers, batcher),
+	}
+}
+
+// Flush writes the pending batches of all the repositories
+func (b *Batcher) Flush() error {
+	b.lock.Lock()
+	buffers := b.buffers
+	b.lock.Unlock()
+
+	var firstErr error
+	for _, buffer := range buffers {
+		if err := buffer.flush(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Run writes the pending batches every interval until stopped.
+// The batches that are pending when stopped are not written, so Flush should be called before the repositories are closed.
+func (b *Batcher) Run(interval time.Duration, logger zerolog.Logger, ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := b.Flush(); err != nil {
+			logger.Error().Err(err).Msg("Failed to write batch, the entities that failed will be retried")
+		}
+	}
+}
+
+func (b *Batcher) add(buffer flusher) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.buffers = append(b.buffers, buffer)
+}
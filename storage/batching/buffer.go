@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"errors"
+	"sync"
+)
+
+// buffer coalesces the writes of a single kind of entity, so that only the last written value of each entity is written in the next batch
+type buffer[T any, U ~string] struct {
+	identify func(T) U
+	merge    func(T, T) T
+	write    func([]T) error
+	size     int
+
+	lock     sync.Mutex
+	flushing sync.Mutex
+	pending  map[U]*T
+}
+
+func newBuffer[T any, U ~string](batcher *Batcher, identify func(T) U, write func([]T) error) *buffer[T, U] {
+	b := &buffer[T, U]{
+		identify: identify,
+		write:    write,
+		size:     batcher.size,
+		pending:  map[U]*T{},
+	}
+	batcher.add(b)
+	return b
+}
+
+// newMergingBuffer creates a buffer that coalesces the writes of an entity using the merge function,
+// for entities where the repository merges the written value with the stored value
+func newMergingBuffer[T any, U ~string](batcher *Batcher, identify func(T) U, merge func(T, T) T, write func([]T) error) *buffer[T, U] {
+	b := newBuffer(batcher, identify, write)
+	b.merge = merge
+	return b
+}
+
+func (b *buffer[T, U]) set(entity T) error {
+	return b.setMany([]T{entity})
+}
+
+// setMany adds the entities to the next batch, and writes the batch if it is full
+func (b *buffer[T, U]) setMany(entities []T) error {
+	b.lock.Lock()
+	for i := range entities {
+		entity := entities[i]
+		uid := b.identify(entity)
+		if previous, ok := b.pending[uid]; ok && b.merge != nil {
+			entity = b.merge(*previous, entity)
+		}
+		b.pending[uid] = &entity
+	}
+	full := len(b.pending) >= b.size
+	b.lock.Unlock()
+
+	if !full {
+		return nil
+	}
+	return b.failedOf(entities, b.flush())
+}
+
+// failedOf returns the error of a flush if it failed to write any of the entities, so that the failures of entities
+// written by other callers are not returned to this caller. These entities are retried in the next batch instead.
+func (b *buffer[T, U]) failedOf(entities []T, err error) error {
+	var failed *WriteFailed
+	if !errors.As(err, &failed) {
+		return err
+	}
+
+	written := make(map[string]struct{}, len(entities))
+	for _, entity := range entities {
+		written[string(b.identify(entity))] = struct{}{}
+	}
+
+	var uids []string
+	for _, uid := range failed.UIDs {
+		if _, ok := written[uid]; ok {
+			uids = append(uids, uid)
+		}
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+	return &WriteFailed{UIDs: uids, Err: failed.Err}
+}
+
+// get returns the entity with the UID from the next batch if there is one, or the stored entity otherwise
+func (b *buffer[T, U]) get(uid U, get func(U) (*T, bool, error)) (*T, bool, error) {
+	b.lock.Lock()
+	entity, ok := b.pending[uid]
+	b.lock.Unlock()
+
+	if ok {
+		copied := *entity
+		return &copied, true, nil
+	}
+	return get(uid)
+}
+
+// flush writes the entities in the next batch. The entities are kept in the buffer until they are written, so that they are still returned by get.
+// If the batch cannot be written, the entities are written one at a time, and the entities that still fail are kept in the buffer
+// to be retried in the next batch, and are returned in a WriteFailed error.
+func (b *buffer[T, U]) flush() error {
+	b.flushing.Lock()
+	defer b.flushing.Unlock()
+
+	b.lock.Lock()
+	if len(b.pending) == 0 {
+		b.lock.Unlock()
+		return nil
+	}
+	batch := make(map[U]*T, len(b.pending))
+	entities := make([]T, 0, len(b.pending))
+	for uid, entity := range b.pending {
+		batch[uid] = entity
+		entities = append(entities, *entity)
+	}
+	b.lock.Unlock()
+
+	var failed map[U]struct{}
+	var err error
+	if batchErr := b.write(entities); batchErr != nil {
+		failed, err = b.writeEach(entities)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// entities that failed, or were set again while writing, are kept for the next batch
+	for uid, entity := range batch {
+		if _, ok := failed[uid]; ok {
+			continue
+		}
+		if b.pending[uid] == entity {
+			delete(b.pending, uid)
+		}
+	}
+	return err
+}
+
+func (b *buffer[T, U]) writeEach(entities []T) (map[U]struct{}, error) {
+	failed := map[U]struct{}{}
+	var uids []string
+	var firstErr error
+	for _, entity := range entities {
+		if err := b.write([]T{entity}); err != nil {
+			uid := b.identify(entity)
+			failed[uid] = struct{}{}
+			uids = append(uids, string(uid))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	if len(uids) > 0 {
+		return failed, &WriteFailed{UIDs: uids, Err: firstErr}
+	}
+	return failed, nil
+}
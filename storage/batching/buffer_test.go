@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"errors"
+	"testing"
+)
+
+var errWriteFailed = errors.New("write failed")
+
+// failingWrites records the written values, and fails the writes of the values in failing
+type failingWrites struct {
+	failing map[string]bool
+	written []string
+}
+
+func (f *failingWrites) write(values []string) error {
+	for _, value := range values {
+		if f.failing[value] {
+			return errWriteFailed
+		}
+	}
+	f.written = append(f.written, values...)
+	return nil
+}
+
+func newFailingBuffer(size int, writes *failingWrites) *buffer[string, string] {
+	return newBuffer(NewBatcher(size), func(value string) string { return value }, writes.write)
+}
+
+func TestFailedEntitiesAreRetriedInTheNextBatch(t *testing.T) {
+	writes := &failingWrites{failing: map[string]bool{"failing": true}}
+	buffer := newFailingBuffer(10, writes)
+
+	if err := buffer.setMany([]string{"failing", "written"}); err != nil {
+		t.Fatal(err)
+	}
+	var failed *WriteFailed
+	if err := buffer.flush(); !errors.As(err, &failed) || len(failed.UIDs) != 1 || failed.UIDs[0] != "failing" {
+		t.Fatalf("expected the failing entity to be returned in a WriteFailed error, got %v", err)
+	}
+
+	delete(writes.failing, "failing")
+	if err := buffer.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(writes.written) != 2 || writes.written[0] != "written" || writes.written[1] != "failing" {
+		t.Errorf("expected the failing entity to be written in the next batch, got %v", writes.written)
+	}
+}
+
+func TestFailuresAreOnlyReturnedToTheWriterOfTheEntity(t *testing.T) {
+	writes := &failingWrites{failing: map[string]bool{"failing": true}}
+	buffer := newFailingBuffer(2, writes)
+
+	if err := buffer.set("failing"); err != nil {
+		t.Fatal(err)
+	}
+	if err := buffer.set("written"); err != nil {
+		t.Errorf("expected the write that filled the batch to succeed, got %v", err)
+	}
+	if err := buffer.setMany([]string{"failing", "other"}); !errors.Is(err, errWriteFailed) {
+		t.Errorf("expected %v when writing the failing entity again, got %v", errWriteFailed, err)
+	}
+}
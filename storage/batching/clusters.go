@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Clusters struct {
+	storage.Clusters
+	clusters *buffer[entities.Cluster, entities.ClusterUID]
+}
+
+func NewClusters(repository storage.Clusters, batcher *Batcher) *Clusters {
+	return &Clusters{
+		Clusters: repository,
+		clusters: newBuffer(batcher, func(cluster entities.Cluster) entities.ClusterUID {
+			return cluster.UID
+		}, repository.SetMany),
+	}
+}
+
+func (c *Clusters) Set(cluster entities.Cluster) error {
+	return c.clusters.set(cluster)
+}
+
+func (c *Clusters) SetMany(clusters []entities.Cluster) error {
+	return c.clusters.setMany(clusters)
+}
+
+func (c *Clusters) List() ([]entities.Cluster, error) {
+	if err := c.clusters.flush(); err != nil {
+		return nil, err
+	}
+	return c.Clusters.List()
+}
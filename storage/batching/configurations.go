@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Configurations struct {
+	storage.Configurations
+	artifacts *buffer[entities.ArtifactConfiguration, entities.ArtifactConfigurationUID]
+	runtimes  *buffer[entities.RuntimeConfiguration, entities.RuntimeConfigurationUID]
+	nodes     *buffer[entities.NodeConfiguration, entities.NodeConfigurationUID]
+}
+
+func NewConfigurations(repository storage.Configurations, batcher *Batcher) *Configurations {
+	return &Configurations{
+		Configurations: repository,
+		artifacts: newBuffer(batcher, func(config entities.ArtifactConfiguration) entities.ArtifactConfigurationUID {
+			return config.UID
+		}, repository.SetManyArtifacts),
+		runtimes: newBuffer(batcher, func(config entities.RuntimeConfiguration) entities.RuntimeConfigurationUID {
+			return config.UID
+		}, repository.SetManyRuntimes),
+		nodes: newBuffer(batcher, func(config entities.NodeConfiguration) entities.NodeConfigurationUID {
+			return config.UID
+		}, repository.SetManyNodes),
+	}
+}
+
+func (c *Configurations) SetArtifact(config entities.ArtifactConfiguration) error {
+	return c.artifacts.set(config)
+}
+
+func (c *Configurations) SetManyArtifacts(configs []entities.ArtifactConfiguration) error {
+	return c.artifacts.setMany(configs)
+}
+
+func (c *Configurations) SetRuntime(config entities.RuntimeConfiguration) error {
+	return c.runtimes.set(config)
+}
+
+func (c *Configurations) SetManyRuntimes(configs []entities.RuntimeConfiguration) error {
+	return c.runtimes.setMany(configs)
+}
+
+func (c *Configurations) SetNode(config entities.NodeConfiguration) error {
+	return c.nodes.set(config)
+}
+
+func (c *Configurations) SetManyNodes(configs []entities.NodeConfiguration) error {
+	return c.nodes.setMany(configs)
+}
+
+func (c *Configurations) ListArtifacts() ([]entities.ArtifactConfiguration, error) {
+	if err := c.artifacts.flush(); err != nil {
+		return nil, err
+	}
+	return c.Configurations.ListArtifacts()
+}
+
+func (c *Configurations) ListRuntimes() ([]entities.RuntimeConfiguration, error) {
+	if err := c.runtimes.flush(); err != nil {
+		return nil, err
+	}
+	return c.Configurations.ListRuntimes()
+}
+
+func (c *Configurations) ListNodes() ([]entities.NodeConfiguration, error) {
+	if err := c.nodes.flush(); err != nil {
+		return nil, err
+	}
+	return c.Configurations.ListNodes()
+}
+
+func (c *Configurations) ListNodesOf(node entities.NodeUID) ([]entities.NodeConfiguration, error) {
+	if err := c.nodes.flush(); err != nil {
+		return nil, err
+	}
+	return c.Configurations.ListNodesOf(node)
+}
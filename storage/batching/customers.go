@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Customers struct {
+	storage.Customers
+	customers *buffer[entities.Customer, entities.CustomerUID]
+	names     *buffer[entities.CustomerName, entities.CustomerNameUID]
+}
+
+func NewCustomers(repository storage.Customers, batcher *Batcher) *Customers {
+	return &Customers{
+		Customers: repository,
+		customers: newBuffer(batcher, func(customer entities.Customer) entities.CustomerUID {
+			return customer.UID
+		}, repository.SetMany),
+		names: newBuffer(batcher, func(name entities.CustomerName) entities.CustomerNameUID {
+			return name.UID
+		}, repository.SetManyNames),
+	}
+}
+
+func (c *Customers) Set(customer entities.Customer) error {
+	return c.customers.set(customer)
+}
+
+func (c *Customers) SetMany(customers []entities.Customer) error {
+	return c.customers.setMany(customers)
+}
+
+func (c *Customers) SetName(name entities.CustomerName) error {
+	return c.names.set(name)
+}
+
+func (c *Customers) SetManyNames(names []entities.CustomerName) error {
+	return c.names.setMany(names)
+}
+
+func (c *Customers) Get(id entities.CustomerUID) (*entities.Customer, bool, error) {
+	return c.customers.get(id, c.Customers.Get)
+}
+
+func (c *Customers) List() ([]entities.Customer, error) {
+	if err := c.customers.flush(); err != nil {
+		return nil, err
+	}
+	return c.Customers.List()
+}
+
+func (c *Customers) GetCurrentName(id entities.CustomerUID) (*entities.CustomerName, bool, error) {
+	if err := c.names.flush(); err != nil {
+		return nil, false, err
+	}
+	return c.Customers.GetCurrentName(id)
+}
+
+func (c *Customers) ListNames() ([]entities.CustomerName, error) {
+	if err := c.names.flush(); err != nil {
+		return nil, err
+	}
+	return c.Customers.ListNames()
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Deployments struct {
+	storage.Deployments
+	deployments *buffer[entities.Deployment, entities.DeploymentUID]
+	instances   *buffer[entities.DeploymentInstance, entities.DeploymentInstanceUID]
+}
+
+func NewDeployments(repository storage.Deployments, batcher *Batcher) *Deployments {
+	return &Deployments{
+		Deployments: repository,
+		deployments: newBuffer(batcher, func(deployment entities.Deployment) entities.DeploymentUID {
+			return deployment.UID
+		}, repository.SetMany),
+		instances: newBuffer(batcher, func(instance entities.DeploymentInstance) entities.DeploymentInstanceUID {
+			return instance.UID
+		}, repository.SetManyInstances),
+	}
+}
+
+func (d *Deployments) Set(deployment entities.Deployment) error {
+	return d.deployments.set(deployment)
+}
+
+func (d *Deployments) SetMany(deployments []entities.Deployment) error {
+	return d.deployments.setMany(deployments)
+}
+
+func (d *Deployments) SetInstance(instance entities.DeploymentInstance) error {
+	return d.instances.set(instance)
+}
+
+func (d *Deployments) SetManyInstances(instances []entities.DeploymentInstance) error {
+	return d.instances.setMany(instances)
+}
+
+func (d *Deployments) Get(id entities.DeploymentUID) (*entities.Deployment, bool, error) {
+	return d.deployments.get(id, d.Deployments.Get)
+}
+
+func (d *Deployments) List() ([]entities.Deployment, error) {
+	if err := d.deployments.flush(); err != nil {
+		return nil, err
+	}
+	return d.Deployments.List()
+}
+
+func (d *Deployments) GetInstance(id entities.DeploymentInstanceUID) (*entities.DeploymentInstance, bool, error) {
+	return d.instances.get(id, d.Deployments.GetInstance)
+}
+
+func (d *Deployments) ListInstances() ([]entities.DeploymentInstance, error) {
+	if err := d.instances.flush(); err != nil {
+		return nil, err
+	}
+	return d.Deployments.ListInstances()
+}
+
+func (d *Deployments) ListRunningInstances() ([]entities.DeploymentInstance, error) {
+	if err := d.instances.flush(); err != nil {
+		return nil, err
+	}
+	return d.Deployments.ListRunningInstances()
+}
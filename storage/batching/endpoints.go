@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Endpoints struct {
+	storage.Endpoints
+	endpoints *buffer[entities.Endpoint, entities.EndpointUID]
+}
+
+func NewEndpoints(repository storage.Endpoints, batcher *Batcher) *Endpoints {
+	return &Endpoints{
+		Endpoints: repository,
+		endpoints: newBuffer(batcher, func(endpoint entities.Endpoint) entities.EndpointUID {
+			return endpoint.UID
+		}, repository.SetMany),
+	}
+}
+
+func (e *Endpoints) Set(endpoint entities.Endpoint) error {
+	return e.endpoints.set(endpoint)
+}
+
+func (e *Endpoints) SetMany(endpoints []entities.Endpoint) error {
+	return e.endpoints.setMany(endpoints)
+}
+
+func (e *Endpoints) List() ([]entities.Endpoint, error) {
+	if err := e.endpoints.flush(); err != nil {
+		return nil, err
+	}
+	return e.Endpoints.List()
+}
+
+func (e *Endpoints) ListExposedIn(environment entities.EnvironmentUID) ([]entities.Endpoint, error) {
+	if err := e.endpoints.flush(); err != nil {
+		return nil, err
+	}
+	return e.Endpoints.ListExposedIn(environment)
+}
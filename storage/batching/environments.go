@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Environments struct {
+	storage.Environments
+	environments *buffer[entities.Environment, entities.EnvironmentUID]
+}
+
+func NewEnvironments(repository storage.Environments, batcher *Batcher) *Environments {
+	return &Environments{
+		Environments: repository,
+		environments: newMergingBuffer(batcher, func(environment entities.Environment) entities.EnvironmentUID {
+			return environment.UID
+		}, entities.MergeEnvironmentClusters, repository.SetMany),
+	}
+}
+
+func (e *Environments) Set(environment entities.Environment) error {
+	return e.environments.set(environment)
+}
+
+func (e *Environments) SetMany(environments []entities.Environment) error {
+	return e.environments.setMany(environments)
+}
+
+func (e *Environments) Get(id entities.EnvironmentUID) (*entities.Environment, bool, error) {
+	return e.environments.get(id, e.Environments.Get)
+}
+
+func (e *Environments) List() ([]entities.Environment, error) {
+	if err := e.environments.flush(); err != nil {
+		return nil, err
+	}
+	return e.Environments.List()
+}
@@ -0,0 +1,26 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WriteFailed is returned when some of the entities in a batch could not be written, even when written one at a time.
+// These entities are kept to be retried in the next batch, unless they are written again with a newer value before that.
+type WriteFailed struct {
+	UIDs []string
+	Err  error
+}
+
+func (e *WriteFailed) Error() string {
+	return fmt.Sprintf("%d entities could not be written and are retried in the next batch (%v): %v", len(e.UIDs), strings.Join(e.UIDs, ", "), e.Err)
+}
+
+func (e *WriteFailed) Unwrap() error {
+	return e.Err
+}
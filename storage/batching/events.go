@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Events struct {
+	storage.Events
+	events *buffer[entities.Event, entities.EventUID]
+}
+
+func NewEvents(repository storage.Events, batcher *Batcher) *Events {
+	return &Events{
+		Events: repository,
+		events: newBuffer(batcher, func(event entities.Event) entities.EventUID {
+			return event.UID
+		}, repository.SetMany),
+	}
+}
+
+func (e *Events) Set(event entities.Event) error {
+	return e.events.set(event)
+}
+
+func (e *Events) SetMany(events []entities.Event) error {
+	return e.events.setMany(events)
+}
+
+func (e *Events) Get(id entities.EventUID) (*entities.Event, bool, error) {
+	return e.events.get(id, e.Events.Get)
+}
+
+func (e *Events) List() ([]entities.Event, error) {
+	if err := e.events.flush(); err != nil {
+		return nil, err
+	}
+	return e.Events.List()
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Images struct {
+	storage.Images
+	releases *buffer[entities.ImageRelease, entities.ImageReleaseUID]
+}
+
+func NewImages(repository storage.Images, batcher *Batcher) *Images {
+	return &Images{
+		Images: repository,
+		releases: newBuffer(batcher, func(release entities.ImageRelease) entities.ImageReleaseUID {
+			return release.UID
+		}, repository.SetManyReleases),
+	}
+}
+
+func (i *Images) SetRelease(release entities.ImageRelease) error {
+	return i.releases.set(release)
+}
+
+func (i *Images) SetManyReleases(releases []entities.ImageRelease) error {
+	return i.releases.setMany(releases)
+}
+
+func (i *Images) GetRelease(id entities.ImageReleaseUID) (*entities.ImageRelease, bool, error) {
+	return i.releases.get(id, i.Images.GetRelease)
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Nodes struct {
+	storage.Nodes
+	nodes  *buffer[entities.Node, entities.NodeUID]
+	events *buffer[entities.NodeEvent, entities.NodeEventUID]
+	pools  *buffer[entities.NodePool, entities.NodePoolUID]
+}
+
+func NewNodes(repository storage.Nodes, batcher *Batcher) *Nodes {
+	return &Nodes{
+		Nodes: repository,
+		nodes: newBuffer(batcher, func(node entities.Node) entities.NodeUID {
+			return node.UID
+		}, repository.SetMany),
+		events: newBuffer(batcher, func(event entities.NodeEvent) entities.NodeEventUID {
+			return event.UID
+		}, repository.SetManyEvents),
+		pools: newBuffer(batcher, func(pool entities.NodePool) entities.NodePoolUID {
+			return pool.UID
+		}, repository.SetManyPools),
+	}
+}
+
+func (n *Nodes) Set(node entities.Node) error {
+	return n.nodes.set(node)
+}
+
+func (n *Nodes) SetMany(nodes []entities.Node) error {
+	return n.nodes.setMany(nodes)
+}
+
+func (n *Nodes) SetEvent(event entities.NodeEvent) error {
+	return n.events.set(event)
+}
+
+func (n *Nodes) SetManyEvents(events []entities.NodeEvent) error {
+	return n.events.setMany(events)
+}
+
+func (n *Nodes) SetPool(pool entities.NodePool) error {
+	return n.pools.set(pool)
+}
+
+func (n *Nodes) SetManyPools(pools []entities.NodePool) error {
+	return n.pools.setMany(pools)
+}
+
+func (n *Nodes) Get(id entities.NodeUID) (*entities.Node, bool, error) {
+	return n.nodes.get(id, n.Nodes.Get)
+}
+
+func (n *Nodes) List() ([]entities.Node, error) {
+	if err := n.nodes.flush(); err != nil {
+		return nil, err
+	}
+	return n.Nodes.List()
+}
+
+func (n *Nodes) ListEvents() ([]entities.NodeEvent, error) {
+	if err := n.events.flush(); err != nil {
+		return nil, err
+	}
+	return n.Nodes.ListEvents()
+}
+
+func (n *Nodes) ListOngoingEvents(id entities.NodeUID) ([]entities.NodeEvent, error) {
+	if err := n.events.flush(); err != nil {
+		return nil, err
+	}
+	return n.Nodes.ListOngoingEvents(id)
+}
+
+func (n *Nodes) ListPools() ([]entities.NodePool, error) {
+	if err := n.pools.flush(); err != nil {
+		return nil, err
+	}
+	return n.Nodes.ListPools()
+}
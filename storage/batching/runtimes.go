@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Runtimes struct {
+	storage.Runtimes
+	versions *buffer[entities.RuntimeVersion, entities.RuntimeVersionUID]
+}
+
+func NewRuntimes(repository storage.Runtimes, batcher *Batcher) *Runtimes {
+	return &Runtimes{
+		Runtimes: repository,
+		versions: newBuffer(batcher, func(version entities.RuntimeVersion) entities.RuntimeVersionUID {
+			return version.UID
+		}, repository.SetManyVersions),
+	}
+}
+
+func (r *Runtimes) SetVersion(version entities.RuntimeVersion) error {
+	return r.versions.set(version)
+}
+
+func (r *Runtimes) SetManyVersions(versions []entities.RuntimeVersion) error {
+	return r.versions.setMany(versions)
+}
+
+func (r *Runtimes) ListVersions() ([]entities.RuntimeVersion, error) {
+	if err := r.versions.flush(); err != nil {
+		return nil, err
+	}
+	return r.Runtimes.ListVersions()
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package batching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Usages struct {
+	storage.Usages
+	usages *buffer[entities.ResourceUsage, entities.ResourceUsageUID]
+}
+
+func NewUsages(repository storage.Usages, batcher *Batcher) *Usages {
+	return &Usages{
+		Usages: repository,
+		usages: newBuffer(batcher, func(usage entities.ResourceUsage) entities.ResourceUsageUID {
+			return usage.UID
+		}, repository.SetMany),
+	}
+}
+
+func (u *Usages) Set(usage entities.ResourceUsage) error {
+	return u.usages.set(usage)
+}
+
+func (u *Usages) SetMany(usages []entities.ResourceUsage) error {
+	return u.usages.setMany(usages)
+}
+
+func (u *Usages) List() ([]entities.ResourceUsage, error) {
+	if err := u.usages.flush(); err != nil {
+		return nil, err
+	}
+	return u.Usages.List()
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Applications struct {
+	storage.Applications
+	cache *Cache
+}
+
+func NewApplications(repository storage.Applications, cache *Cache) *Applications {
+	return &Applications{
+		Applications: repository,
+		cache:        cache,
+	}
+}
+
+func (a *Applications) Set(application entities.Application) error {
+	return a.cache.write(application.Type, string(application.UID), application, func() error {
+		return a.Applications.Set(application)
+	})
+}
+
+func (a *Applications) SetMany(applications []entities.Application) error {
+	return writeMany(a.cache, applications, func(application entities.Application) (string, string) {
+		return application.Type, string(application.UID)
+	}, a.Applications.SetMany)
+}
+
+func (a *Applications) SetName(name entities.ApplicationName) error {
+	return a.cache.write(name.Type, string(name.UID), name, func() error {
+		return a.Applications.SetName(name)
+	})
+}
+
+func (a *Applications) SetManyNames(names []entities.ApplicationName) error {
+	return writeMany(a.cache, names, func(name entities.ApplicationName) (string, string) {
+		return name.Type, string(name.UID)
+	}, a.Applications.SetManyNames)
+}
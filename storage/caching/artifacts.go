@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Artifacts struct {
+	storage.Artifacts
+	cache *Cache
+}
+
+func NewArtifacts(repository storage.Artifacts, cache *Cache) *Artifacts {
+	return &Artifacts{
+		Artifacts: repository,
+		cache:     cache,
+	}
+}
+
+func (a *Artifacts) Set(artifact entities.Artifact) error {
+	return a.cache.write(artifact.Type, string(artifact.UID), artifact, func() error {
+		return a.Artifacts.Set(artifact)
+	})
+}
+
+func (a *Artifacts) SetMany(artifacts []entities.Artifact) error {
+	return writeMany(a.cache, artifacts, func(artifact entities.Artifact) (string, string) {
+		return artifact.Type, string(artifact.UID)
+	}, a.Artifacts.SetMany)
+}
+
+func (a *Artifacts) SetVersion(version entities.ArtifactVersion) error {
+	return a.cache.write(version.Type, string(version.UID), version, func() error {
+		return a.Artifacts.SetVersion(version)
+	})
+}
+
+func (a *Artifacts) SetManyVersions(versions []entities.ArtifactVersion) error {
+	return writeMany(a.cache, versions, func(version entities.ArtifactVersion) (string, string) {
+		return version.Type, string(version.UID)
+	}, a.Artifacts.SetManyVersions)
+}
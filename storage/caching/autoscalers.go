@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Autoscalers struct {
+	storage.Autoscalers
+	cache *Cache
+}
+
+func NewAutoscalers(repository storage.Autoscalers, cache *Cache) *Autoscalers {
+	return &Autoscalers{
+		Autoscalers: repository,
+		cache:       cache,
+	}
+}
+
+func (a *Autoscalers) Set(autoscaler entities.Autoscaler) error {
+	return a.cache.write(autoscaler.Type, string(autoscaler.UID), autoscaler, func() error {
+		return a.Autoscalers.Set(autoscaler)
+	})
+}
+
+func (a *Autoscalers) SetMany(autoscalers []entities.Autoscaler) error {
+	return writeMany(a.cache, autoscalers, func(autoscaler entities.Autoscaler) (string, string) {
+		return autoscaler.Type, string(autoscaler.UID)
+	}, a.Autoscalers.SetMany)
+}
+
+func (a *Autoscalers) SetEvent(event entities.ScaledEvent) error {
+	return a.cache.write(event.Type, string(event.UID), event, func() error {
+		return a.Autoscalers.SetEvent(event)
+	})
+}
+
+func (a *Autoscalers) SetManyEvents(events []entities.ScaledEvent) error {
+	return writeMany(a.cache, events, func(event entities.ScaledEvent) (string, string) {
+		return event.Type, string(event.UID)
+	}, a.Autoscalers.SetManyEvents)
+}
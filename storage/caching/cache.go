@@ -0,0 +1,240 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"github.com/rs/zerolog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stats are the number of writes that were skipped because the entity was unchanged (hits), the number of writes that were passed on (misses),
+// the number of entities that were removed from the cache to keep it within its size, and the current number of entities in the cache
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+}
+
+// Cache remembers the last written value of the most recently written entities, so that writing an unchanged entity can be skipped.
+// Writes of entities with the same type and UID are serialised, so that the cached value is always the value that was written last.
+type Cache struct {
+	size    int
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	keys    map[string]*keyLock
+	stats   Stats
+}
+
+type entry struct {
+	key   string
+	value []byte
+}
+
+type keyLock struct {
+	sync.Mutex
+	holders int
+}
+
+// NewCache creates a Cache that holds at most size entities
+func NewCache(size int) *Cache {
+	return &Cache{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+		keys:    map[string]*keyLock{},
+	}
+}
+
+// Stats returns the current statistics of the cache
+func (c *Cache) Stats() Stats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
+
+// LogStats logs the statistics of the cache every interval until stopped
+func (c *Cache) LogStats(interval time.Duration, logger zerolog.Logger, ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats := c.Stats()
+		logger.Info().Uint64("hits", stats.Hits).Uint64("misses", stats.Misses).Uint64("evictions", stats.Evictions).Int("size", stats.Size).Msg("Storage cache statistics")
+	}
+}
+
+// write calls the write function if the entity is different from the last written value with the same type and UID
+func (c *Cache) write(entityType, uid string, entity any, write func() error) error {
+	value, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+
+	key := entityType + "/" + uid
+	defer c.lockKeys([]string{key})()
+
+	if c.unchanged(key, value) {
+		return nil
+	}
+
+	if err := write(); err != nil {
+		c.remove(key)
+		return err
+	}
+
+	c.add(key, value)
+	return nil
+}
+
+// writeMany calls the write function with the entities that are different from the last written values with the same type and UID
+func writeMany[T any](c *Cache, entities []T, identify func(T) (string, string), write func([]T) error) error {
+	allKeys := make([]string, 0, len(entities))
+	allValues := make([][]byte, 0, len(entities))
+	for _, entity := range entities {
+		value, err := json.Marshal(entity)
+		if err != nil {
+			return err
+		}
+
+		entityType, uid := identify(entity)
+		allKeys = append(allKeys, entityType+"/"+uid)
+		allValues = append(allValues, value)
+	}
+
+	defer c.lockKeys(allKeys)()
+
+	var changed []T
+	var keys []string
+	var values [][]byte
+	for i, entity := range entities {
+		if c.unchanged(allKeys[i], allValues[i]) {
+			continue
+		}
+
+		changed = append(changed, entity)
+		keys = append(keys, allKeys[i])
+		values = append(values, allValues[i])
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if err := write(changed); err != nil {
+		for _, key := range keys {
+			c.remove(key)
+		}
+		return err
+	}
+
+	for i, key := range keys {
+		c.add(key, values[i])
+	}
+	return nil
+}
+
+// lockKeys waits until no other write holds any of the keys, and returns a function that releases them.
+// The keys are locked in sorted order, so that concurrent writes of overlapping keys cannot deadlock.
+func (c *Cache) lockKeys(keys []string) func() {
+	sorted := make([]string, 0, len(keys))
+	seen := map[string]struct{}{}
+	for _, key := range keys {
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			sorted = append(sorted, key)
+		}
+	}
+	sort.Strings(sorted)
+
+	locks := make([]*keyLock, 0, len(sorted))
+	for _, key := range sorted {
+		c.lock.Lock()
+		lock, ok := c.keys[key]
+		if !ok {
+			lock = &keyLock{}
+			c.keys[key] = lock
+		}
+		lock.holders++
+		c.lock.Unlock()
+
+		lock.Lock()
+		locks = append(locks, lock)
+	}
+
+	return func() {
+		for i, lock := range locks {
+			lock.Unlock()
+
+			c.lock.Lock()
+			lock.holders--
+			if lock.holders == 0 {
+				delete(c.keys, sorted[i])
+			}
+			c.lock.Unlock()
+		}
+	}
+}
+
+// unchanged returns true if the value is the same as the last written value with the key, and counts the hit or miss
+func (c *Cache) unchanged(key string, value []byte) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if element, ok := c.entries[key]; ok && bytes.Equal(element.Value.(*entry).value, value) {
+		c.order.MoveToFront(element)
+		c.stats.Hits++
+		return true
+	}
+
+	c.stats.Misses++
+	return false
+}
+
+func (c *Cache) add(key string, value []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*entry).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache) remove(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+}
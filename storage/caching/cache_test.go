@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+	"dolittle.io/fleet-observer/storage/batching"
+	"errors"
+	"testing"
+)
+
+var errWriteFailed = errors.New("write failed")
+
+// failingArtifacts is an Artifacts repository that records the written artifacts, and fails the writes while failing is set
+type failingArtifacts struct {
+	storage.Artifacts
+	failing bool
+	written []entities.Artifact
+}
+
+func (f *failingArtifacts) Set(artifact entities.Artifact) error {
+	return f.SetMany([]entities.Artifact{artifact})
+}
+
+func (f *failingArtifacts) SetMany(artifacts []entities.Artifact) error {
+	if f.failing {
+		return errWriteFailed
+	}
+	f.written = append(f.written, artifacts...)
+	return nil
+}
+
+func TestUnchangedWritesAreSkipped(t *testing.T) {
+	inner := &failingArtifacts{}
+	artifacts := NewArtifacts(inner, NewCache(10))
+	artifact := entities.NewArtifact("customer", "microservice")
+
+	for i := 0; i < 2; i++ {
+		if err := artifacts.Set(artifact); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(inner.written) != 1 {
+		t.Errorf("expected 1 write, got %d", len(inner.written))
+	}
+}
+
+func TestFailedSetIsWrittenAgain(t *testing.T) {
+	inner := &failingArtifacts{failing: true}
+	artifacts := NewArtifacts(inner, NewCache(10))
+	artifact := entities.NewArtifact("customer", "microservice")
+
+	if err := artifacts.Set(artifact); !errors.Is(err, errWriteFailed) {
+		t.Fatalf("expected %v, got %v", errWriteFailed, err)
+	}
+
+	inner.failing = false
+	if err := artifacts.Set(artifact); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(inner.written) != 1 {
+		t.Errorf("expected the artifact to be written again, got %d writes", len(inner.written))
+	}
+}
+
+func TestFailedSetManyIsWrittenAgain(t *testing.T) {
+	inner := &failingArtifacts{failing: true}
+	artifacts := NewArtifacts(inner, NewCache(10))
+	batch := []entities.Artifact{
+		entities.NewArtifact("customer", "first"),
+		entities.NewArtifact("customer", "second"),
+	}
+
+	if err := artifacts.SetMany(batch); !errors.Is(err, errWriteFailed) {
+		t.Fatalf("expected %v, got %v", errWriteFailed, err)
+	}
+
+	inner.failing = false
+	if err := artifacts.SetMany(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(inner.written) != 2 {
+		t.Errorf("expected both artifacts to be written again, got %d writes", len(inner.written))
+	}
+}
+
+func TestFailedFlushOfBatchIsWrittenAgain(t *testing.T) {
+	inner := &failingArtifacts{failing: true}
+	batcher := batching.NewBatcher(10)
+	artifacts := batching.NewArtifacts(NewArtifacts(inner, NewCache(10)), batcher)
+	artifact := entities.NewArtifact("customer", "microservice")
+
+	if err := artifacts.Set(artifact); err != nil {
+		t.Fatal(err)
+	}
+	if err := batcher.Flush(); !errors.Is(err, errWriteFailed) {
+		t.Fatalf("expected %v, got %v", errWriteFailed, err)
+	}
+
+	inner.failing = false
+	if err := artifacts.Set(artifact); err != nil {
+		t.Fatal(err)
+	}
+	if err := batcher.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(inner.written) != 1 {
+		t.Errorf("expected the artifact to be written after the failed flush, got %d writes", len(inner.written))
+	}
+}
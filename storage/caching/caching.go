@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/storage"
+)
+
+// Wrap creates repositories that skip writing entities to the provided repositories if they are unchanged since they were last written.
+// An entity is only remembered when the provided repository returns without an error, so the provided repositories must write synchronously,
+// and should not be batching repositories that return before the entities are written.
+func Wrap(repositories *storage.Repositories, cache *Cache) *storage.Repositories {
+	return &storage.Repositories{
+		Clusters:       NewClusters(repositories.Clusters, cache),
+		Nodes:          NewNodes(repositories.Nodes, cache),
+		Customers:      NewCustomers(repositories.Customers, cache),
+		Applications:   NewApplications(repositories.Applications, cache),
+		Environments:   NewEnvironments(repositories.Environments, cache),
+		Artifacts:      NewArtifacts(repositories.Artifacts, cache),
+		Runtimes:       NewRuntimes(repositories.Runtimes, cache),
+		Deployments:    NewDeployments(repositories.Deployments, cache),
+		Configurations: NewConfigurations(repositories.Configurations, cache),
+		Events:         NewEvents(repositories.Events, cache),
+		Usages:         NewUsages(repositories.Usages, cache),
+		Images:         NewImages(repositories.Images, cache),
+		Endpoints:      NewEndpoints(repositories.Endpoints, cache),
+		Autoscalers:    NewAutoscalers(repositories.Autoscalers, cache),
+	}
+}
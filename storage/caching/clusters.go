@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Clusters struct {
+	storage.Clusters
+	cache *Cache
+}
+
+func NewClusters(repository storage.Clusters, cache *Cache) *Clusters {
+	return &Clusters{
+		Clusters: repository,
+		cache:    cache,
+	}
+}
+
+func (c *Clusters) Set(cluster entities.Cluster) error {
+	return c.cache.write(cluster.Type, string(cluster.UID), cluster, func() error {
+		return c.Clusters.Set(cluster)
+	})
+}
+
+func (c *Clusters) SetMany(clusters []entities.Cluster) error {
+	return writeMany(c.cache, clusters, func(cluster entities.Cluster) (string, string) {
+		return cluster.Type, string(cluster.UID)
+	}, c.Clusters.SetMany)
+}
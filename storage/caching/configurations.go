@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Configurations struct {
+	storage.Configurations
+	cache *Cache
+}
+
+func NewConfigurations(repository storage.Configurations, cache *Cache) *Configurations {
+	return &Configurations{
+		Configurations: repository,
+		cache:          cache,
+	}
+}
+
+func (c *Configurations) SetArtifact(config entities.ArtifactConfiguration) error {
+	return c.cache.write(config.Type, string(config.UID), config, func() error {
+		return c.Configurations.SetArtifact(config)
+	})
+}
+
+func (c *Configurations) SetManyArtifacts(configs []entities.ArtifactConfiguration) error {
+	return writeMany(c.cache, configs, func(config entities.ArtifactConfiguration) (string, string) {
+		return config.Type, string(config.UID)
+	}, c.Configurations.SetManyArtifacts)
+}
+
+func (c *Configurations) SetRuntime(config entities.RuntimeConfiguration) error {
+	return c.cache.write(config.Type, string(config.UID), config, func() error {
+		return c.Configurations.SetRuntime(config)
+	})
+}
+
+func (c *Configurations) SetManyRuntimes(configs []entities.RuntimeConfiguration) error {
+	return writeMany(c.cache, configs, func(config entities.RuntimeConfiguration) (string, string) {
+		return config.Type, string(config.UID)
+	}, c.Configurations.SetManyRuntimes)
+}
+
+func (c *Configurations) SetNode(config entities.NodeConfiguration) error {
+	return c.cache.write(config.Type, string(config.UID), config, func() error {
+		return c.Configurations.SetNode(config)
+	})
+}
+
+func (c *Configurations) SetManyNodes(configs []entities.NodeConfiguration) error {
+	return writeMany(c.cache, configs, func(config entities.NodeConfiguration) (string, string) {
+		return config.Type, string(config.UID)
+	}, c.Configurations.SetManyNodes)
+}
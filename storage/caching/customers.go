@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Customers struct {
+	storage.Customers
+	cache *Cache
+}
+
+func NewCustomers(repository storage.Customers, cache *Cache) *Customers {
+	return &Customers{
+		Customers: repository,
+		cache:     cache,
+	}
+}
+
+func (c *Customers) Set(customer entities.Customer) error {
+	return c.cache.write(customer.Type, string(customer.UID), customer, func() error {
+		return c.Customers.Set(customer)
+	})
+}
+
+func (c *Customers) SetMany(customers []entities.Customer) error {
+	return writeMany(c.cache, customers, func(customer entities.Customer) (string, string) {
+		return customer.Type, string(customer.UID)
+	}, c.Customers.SetMany)
+}
+
+func (c *Customers) SetName(name entities.CustomerName) error {
+	return c.cache.write(name.Type, string(name.UID), name, func() error {
+		return c.Customers.SetName(name)
+	})
+}
+
+func (c *Customers) SetManyNames(names []entities.CustomerName) error {
+	return writeMany(c.cache, names, func(name entities.CustomerName) (string, string) {
+		return name.Type, string(name.UID)
+	}, c.Customers.SetManyNames)
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Deployments struct {
+	storage.Deployments
+	cache *Cache
+}
+
+func NewDeployments(repository storage.Deployments, cache *Cache) *Deployments {
+	return &Deployments{
+		Deployments: repository,
+		cache:       cache,
+	}
+}
+
+func (d *Deployments) Set(deployment entities.Deployment) error {
+	return d.cache.write(deployment.Type, string(deployment.UID), deployment, func() error {
+		return d.Deployments.Set(deployment)
+	})
+}
+
+func (d *Deployments) SetMany(deployments []entities.Deployment) error {
+	return writeMany(d.cache, deployments, func(deployment entities.Deployment) (string, string) {
+		return deployment.Type, string(deployment.UID)
+	}, d.Deployments.SetMany)
+}
+
+func (d *Deployments) SetInstance(instance entities.DeploymentInstance) error {
+	return d.cache.write(instance.Type, string(instance.UID), instance, func() error {
+		return d.Deployments.SetInstance(instance)
+	})
+}
+
+func (d *Deployments) SetManyInstances(instances []entities.DeploymentInstance) error {
+	return writeMany(d.cache, instances, func(instance entities.DeploymentInstance) (string, string) {
+		return instance.Type, string(instance.UID)
+	}, d.Deployments.SetManyInstances)
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Endpoints struct {
+	storage.Endpoints
+	cache *Cache
+}
+
+func NewEndpoints(repository storage.Endpoints, cache *Cache) *Endpoints {
+	return &Endpoints{
+		Endpoints: repository,
+		cache:     cache,
+	}
+}
+
+func (e *Endpoints) Set(endpoint entities.Endpoint) error {
+	return e.cache.write(endpoint.Type, string(endpoint.UID), endpoint, func() error {
+		return e.Endpoints.Set(endpoint)
+	})
+}
+
+func (e *Endpoints) SetMany(endpoints []entities.Endpoint) error {
+	return writeMany(e.cache, endpoints, func(endpoint entities.Endpoint) (string, string) {
+		return endpoint.Type, string(endpoint.UID)
+	}, e.Endpoints.SetMany)
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Environments struct {
+	storage.Environments
+	cache *Cache
+}
+
+func NewEnvironments(repository storage.Environments, cache *Cache) *Environments {
+	return &Environments{
+		Environments: repository,
+		cache:        cache,
+	}
+}
+
+func (e *Environments) Set(environment entities.Environment) error {
+	return e.cache.write(environment.Type, string(environment.UID), environment, func() error {
+		return e.Environments.Set(environment)
+	})
+}
+
+func (e *Environments) SetMany(environments []entities.Environment) error {
+	return writeMany(e.cache, environments, func(environment entities.Environment) (string, string) {
+		return environment.Type, string(environment.UID)
+	}, e.Environments.SetMany)
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Events struct {
+	storage.Events
+	cache *Cache
+}
+
+func NewEvents(repository storage.Events, cache *Cache) *Events {
+	return &Events{
+		Events: repository,
+		cache:  cache,
+	}
+}
+
+func (e *Events) Set(event entities.Event) error {
+	return e.cache.write(event.Type, string(event.UID), event, func() error {
+		return e.Events.Set(event)
+	})
+}
+
+func (e *Events) SetMany(events []entities.Event) error {
+	return writeMany(e.cache, events, func(event entities.Event) (string, string) {
+		return event.Type, string(event.UID)
+	}, e.Events.SetMany)
+}
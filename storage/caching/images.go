@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Images struct {
+	storage.Images
+	cache *Cache
+}
+
+func NewImages(repository storage.Images, cache *Cache) *Images {
+	return &Images{
+		Images: repository,
+		cache:  cache,
+	}
+}
+
+func (i *Images) SetRelease(release entities.ImageRelease) error {
+	return i.cache.write(release.Type, string(release.UID), release, func() error {
+		return i.Images.SetRelease(release)
+	})
+}
+
+func (i *Images) SetManyReleases(releases []entities.ImageRelease) error {
+	return writeMany(i.cache, releases, func(release entities.ImageRelease) (string, string) {
+		return release.Type, string(release.UID)
+	}, i.Images.SetManyReleases)
+}
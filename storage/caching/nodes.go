@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Nodes struct {
+	storage.Nodes
+	cache *Cache
+}
+
+func NewNodes(repository storage.Nodes, cache *Cache) *Nodes {
+	return &Nodes{
+		Nodes: repository,
+		cache: cache,
+	}
+}
+
+func (n *Nodes) Set(node entities.Node) error {
+	return n.cache.write(node.Type, string(node.UID), node, func() error {
+		return n.Nodes.Set(node)
+	})
+}
+
+func (n *Nodes) SetMany(nodes []entities.Node) error {
+	return writeMany(n.cache, nodes, func(node entities.Node) (string, string) {
+		return node.Type, string(node.UID)
+	}, n.Nodes.SetMany)
+}
+
+func (n *Nodes) SetEvent(event entities.NodeEvent) error {
+	return n.cache.write(event.Type, string(event.UID), event, func() error {
+		return n.Nodes.SetEvent(event)
+	})
+}
+
+func (n *Nodes) SetManyEvents(events []entities.NodeEvent) error {
+	return writeMany(n.cache, events, func(event entities.NodeEvent) (string, string) {
+		return event.Type, string(event.UID)
+	}, n.Nodes.SetManyEvents)
+}
+
+func (n *Nodes) SetPool(pool entities.NodePool) error {
+	return n.cache.write(pool.Type, string(pool.UID), pool, func() error {
+		return n.Nodes.SetPool(pool)
+	})
+}
+
+func (n *Nodes) SetManyPools(pools []entities.NodePool) error {
+	return writeMany(n.cache, pools, func(pool entities.NodePool) (string, string) {
+		return pool.Type, string(pool.UID)
+	}, n.Nodes.SetManyPools)
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Runtimes struct {
+	storage.Runtimes
+	cache *Cache
+}
+
+func NewRuntimes(repository storage.Runtimes, cache *Cache) *Runtimes {
+	return &Runtimes{
+		Runtimes: repository,
+		cache:    cache,
+	}
+}
+
+func (r *Runtimes) SetVersion(version entities.RuntimeVersion) error {
+	return r.cache.write(version.Type, string(version.UID), version, func() error {
+		return r.Runtimes.SetVersion(version)
+	})
+}
+
+func (r *Runtimes) SetManyVersions(versions []entities.RuntimeVersion) error {
+	return writeMany(r.cache, versions, func(version entities.RuntimeVersion) (string, string) {
+		return version.Type, string(version.UID)
+	}, r.Runtimes.SetManyVersions)
+}
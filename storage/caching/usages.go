@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package caching
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Usages struct {
+	storage.Usages
+	cache *Cache
+}
+
+func NewUsages(repository storage.Usages, cache *Cache) *Usages {
+	return &Usages{
+		Usages: repository,
+		cache:  cache,
+	}
+}
+
+func (u *Usages) Set(usage entities.ResourceUsage) error {
+	return u.cache.write(usage.Type, string(usage.UID), usage, func() error {
+		return u.Usages.Set(usage)
+	})
+}
+
+func (u *Usages) SetMany(usages []entities.ResourceUsage) error {
+	return writeMany(u.cache, usages, func(usage entities.ResourceUsage) (string, string) {
+		return usage.Type, string(usage.UID)
+	}, u.Usages.SetMany)
+}
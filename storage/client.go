@@ -22,21 +22,26 @@ func Connect(config *koanf.Koanf, logger zerolog.Logger, ctx context.Context) (*
 	logger = logger.With().Str("component", "storage").Logger()
 	if config.String("neo4j.connection-string") != "" {
 		logger.Info().Msg("Using Neo4j for storage")
-		session, err := neo4j.ConnectToNeo4j(config, logger, ctx)
+		driver, err := neo4j.ConnectToNeo4j(config, logger, ctx)
 		if err != nil {
 			return nil, err
 		}
 
 		return &Repositories{
-			Nodes:          neo4j.NewNodes(session, ctx),
-			Customers:      neo4j.NewCustomers(session, ctx),
-			Applications:   neo4j.NewApplications(session, ctx),
-			Environments:   neo4j.NewEnvironments(session, ctx),
-			Artifacts:      neo4j.NewArtifacts(session, ctx),
-			Runtimes:       neo4j.NewRuntimes(session, ctx),
-			Deployments:    neo4j.NewDeployments(session, ctx),
-			Configurations: neo4j.NewConfigurations(session, ctx),
-			Events:         neo4j.NewEvents(session, ctx),
+			Clusters:       neo4j.NewClusters(driver, ctx),
+			Nodes:          neo4j.NewNodes(driver, ctx),
+			Customers:      neo4j.NewCustomers(driver, ctx),
+			Applications:   neo4j.NewApplications(driver, ctx),
+			Environments:   neo4j.NewEnvironments(driver, ctx),
+			Artifacts:      neo4j.NewArtifacts(driver, ctx),
+			Runtimes:       neo4j.NewRuntimes(driver, ctx),
+			Deployments:    neo4j.NewDeployments(driver, ctx),
+			Configurations: neo4j.NewConfigurations(driver, ctx),
+			Events:         neo4j.NewEvents(driver, ctx),
+			Usages:         neo4j.NewUsages(driver, ctx),
+			Images:         neo4j.NewImages(driver, ctx),
+			Endpoints:      neo4j.NewEndpoints(driver, ctx),
+			Autoscalers:    neo4j.NewAutoscalers(driver, ctx),
 		}, nil
 	}
 
@@ -48,6 +53,7 @@ func Connect(config *koanf.Koanf, logger zerolog.Logger, ctx context.Context) (*
 		}
 
 		return &Repositories{
+			Clusters:       mongo.NewClusters(database, ctx),
 			Nodes:          mongo.NewNodes(database, ctx),
 			Customers:      mongo.NewCustomers(database, ctx),
 			Applications:   mongo.NewApplications(database, ctx),
@@ -57,6 +63,10 @@ func Connect(config *koanf.Koanf, logger zerolog.Logger, ctx context.Context) (*
 			Deployments:    mongo.NewDeployments(database, ctx),
 			Configurations: mongo.NewConfigurations(database, ctx),
 			Events:         mongo.NewEvents(database, ctx),
+			Usages:         mongo.NewUsages(database, ctx),
+			Images:         mongo.NewImages(database, ctx),
+			Endpoints:      mongo.NewEndpoints(database, ctx),
+			Autoscalers:    mongo.NewAutoscalers(database, ctx),
 		}, nil
 	}
 
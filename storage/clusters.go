@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package storage
+
+import "dolittle.io/fleet-observer/entities"
+
+type Clusters interface {
+	Set(cluster entities.Cluster) error
+	SetMany(clusters []entities.Cluster) error
+	List() ([]entities.Cluster, error)
+}
@@ -9,7 +9,14 @@ import "dolittle.io/fleet-observer/entities"
 
 type Configurations interface {
 	SetArtifact(config entities.ArtifactConfiguration) error
+	SetManyArtifacts(configs []entities.ArtifactConfiguration) error
 	ListArtifacts() ([]entities.ArtifactConfiguration, error)
 	SetRuntime(config entities.RuntimeConfiguration) error
+	SetManyRuntimes(configs []entities.RuntimeConfiguration) error
 	ListRuntimes() ([]entities.RuntimeConfiguration, error)
+	SetNode(config entities.NodeConfiguration) error
+	SetManyNodes(configs []entities.NodeConfiguration) error
+	ListNodes() ([]entities.NodeConfiguration, error)
+	// ListNodesOf lists the configurations the node has had
+	ListNodesOf(node entities.NodeUID) ([]entities.NodeConfiguration, error)
 }
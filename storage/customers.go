@@ -9,5 +9,11 @@ import "dolittle.io/fleet-observer/entities"
 
 type Customers interface {
 	Set(customer entities.Customer) error
+	SetMany(customers []entities.Customer) error
+	Get(id entities.CustomerUID) (*entities.Customer, bool, error)
 	List() ([]entities.Customer, error)
+	SetName(name entities.CustomerName) error
+	SetManyNames(names []entities.CustomerName) error
+	GetCurrentName(id entities.CustomerUID) (*entities.CustomerName, bool, error)
+	ListNames() ([]entities.CustomerName, error)
 }
@@ -9,9 +9,11 @@ import "dolittle.io/fleet-observer/entities"
 
 type Deployments interface {
 	Set(deployment entities.Deployment) error
+	SetMany(deployments []entities.Deployment) error
 	Get(id entities.DeploymentUID) (*entities.Deployment, bool, error)
 	List() ([]entities.Deployment, error)
 	SetInstance(instance entities.DeploymentInstance) error
+	SetManyInstances(instances []entities.DeploymentInstance) error
 	GetInstance(id entities.DeploymentInstanceUID) (*entities.DeploymentInstance, bool, error)
 	ListInstances() ([]entities.DeploymentInstance, error)
 	ListRunningInstances() ([]entities.DeploymentInstance, error)
@@ -0,0 +1,15 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package storage
+
+import "dolittle.io/fleet-observer/entities"
+
+type Endpoints interface {
+	Set(endpoint entities.Endpoint) error
+	SetMany(endpoints []entities.Endpoint) error
+	List() ([]entities.Endpoint, error)
+	ListExposedIn(environment entities.EnvironmentUID) ([]entities.Endpoint, error)
+}
@@ -8,7 +8,9 @@ package storage
 import "dolittle.io/fleet-observer/entities"
 
 type Environments interface {
+	// Set sets the environment, and adds its clusters to the stored clusters instead of replacing them
 	Set(environment entities.Environment) error
+	SetMany(environments []entities.Environment) error
 	Get(id entities.EnvironmentUID) (*entities.Environment, bool, error)
 	List() ([]entities.Environment, error)
 }
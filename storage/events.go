@@ -9,6 +9,7 @@ import "dolittle.io/fleet-observer/entities"
 
 type Events interface {
 	Set(event entities.Event) error
+	SetMany(events []entities.Event) error
 	Get(id entities.EventUID) (*entities.Event, bool, error)
 	List() ([]entities.Event, error)
 }
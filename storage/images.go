@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package storage
+
+import "dolittle.io/fleet-observer/entities"
+
+type Images interface {
+	SetRelease(release entities.ImageRelease) error
+	SetManyReleases(releases []entities.ImageRelease) error
+	GetRelease(id entities.ImageReleaseUID) (*entities.ImageRelease, bool, error)
+}
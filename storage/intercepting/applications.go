@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Applications struct {
+	repository   storage.Applications
+	applications *table[entities.Application, entities.ApplicationUID]
+	names        *table[entities.ApplicationName, entities.ApplicationNameUID]
+}
+
+func NewApplications(repository storage.Applications, interceptor Interceptor, size int) *Applications {
+	return &Applications{
+		repository: repository,
+		applications: newGetTable(interceptor, size, func(application entities.Application) (string, entities.ApplicationUID) {
+			return application.Type, application.UID
+		}, repository.Get),
+		names: newListTable(interceptor, size, func(name entities.ApplicationName) (string, entities.ApplicationNameUID) {
+			return name.Type, name.UID
+		}, repository.ListNames),
+	}
+}
+
+func (a *Applications) Set(application entities.Application) error {
+	return a.applications.set(application, a.repository.Set)
+}
+
+func (a *Applications) SetMany(applications []entities.Application) error {
+	return a.applications.setMany(applications, a.repository.SetMany)
+}
+
+func (a *Applications) Get(id entities.ApplicationUID) (*entities.Application, bool, error) {
+	return a.applications.getOne(id, a.repository.Get)
+}
+
+func (a *Applications) List() ([]entities.Application, error) {
+	return a.applications.listAll(a.repository.List)
+}
+
+func (a *Applications) SetName(name entities.ApplicationName) error {
+	return a.names.set(name, a.repository.SetName)
+}
+
+func (a *Applications) SetManyNames(names []entities.ApplicationName) error {
+	return a.names.setMany(names, a.repository.SetManyNames)
+}
+
+func (a *Applications) GetCurrentName(id entities.ApplicationUID) (*entities.ApplicationName, bool, error) {
+	names, err := a.names.listMatching(func() ([]entities.ApplicationName, error) {
+		name, exists, err := a.repository.GetCurrentName(id)
+		if err != nil || !exists {
+			return nil, err
+		}
+		return []entities.ApplicationName{*name}, nil
+	}, func(name entities.ApplicationName) bool {
+		return name.Links.NameOfApplicationUID == id && name.Properties.To == nil
+	})
+	if err != nil || len(names) == 0 {
+		return nil, false, err
+	}
+	return &names[0], true, nil
+}
+
+func (a *Applications) ListNames() ([]entities.ApplicationName, error) {
+	return a.names.listAll(a.repository.ListNames)
+}
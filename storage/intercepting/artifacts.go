@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Artifacts struct {
+	repository storage.Artifacts
+	artifacts  *table[entities.Artifact, entities.ArtifactUID]
+	versions   *table[entities.ArtifactVersion, entities.ArtifactVersionUID]
+}
+
+func NewArtifacts(repository storage.Artifacts, interceptor Interceptor, size int) *Artifacts {
+	return &Artifacts{
+		repository: repository,
+		artifacts: newListTable(interceptor, size, func(artifact entities.Artifact) (string, entities.ArtifactUID) {
+			return artifact.Type, artifact.UID
+		}, repository.List),
+		versions: newMergingGetTable(interceptor, size, func(version entities.ArtifactVersion) (string, entities.ArtifactVersionUID) {
+			return version.Type, version.UID
+		}, entities.MergeArtifactVersionDigests, repository.GetVersion),
+	}
+}
+
+func (a *Artifacts) Set(artifact entities.Artifact) error {
+	return a.artifacts.set(artifact, a.repository.Set)
+}
+
+func (a *Artifacts) SetMany(artifacts []entities.Artifact) error {
+	return a.artifacts.setMany(artifacts, a.repository.SetMany)
+}
+
+func (a *Artifacts) List() ([]entities.Artifact, error) {
+	return a.artifacts.listAll(a.repository.List)
+}
+
+func (a *Artifacts) SetVersion(version entities.ArtifactVersion) error {
+	return a.versions.set(version, a.repository.SetVersion)
+}
+
+func (a *Artifacts) SetManyVersions(versions []entities.ArtifactVersion) error {
+	return a.versions.setMany(versions, a.repository.SetManyVersions)
+}
+
+func (a *Artifacts) AddVersionDigest(id entities.ArtifactVersionUID, digest string) ([]string, bool, error) {
+	version, exists, err := a.versions.getOne(id, a.repository.GetVersion)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	for _, known := range version.Properties.Digests {
+		if known == digest {
+			return nil, false, nil
+		}
+	}
+
+	previous := version.Properties.Digests
+	updated := *version
+	updated.Properties.Digests = []string{digest}
+	return previous, true, a.versions.set(updated, func(entities.ArtifactVersion) error {
+		_, _, err := a.repository.AddVersionDigest(id, digest)
+		return err
+	})
+}
+
+func (a *Artifacts) GetVersion(id entities.ArtifactVersionUID) (*entities.ArtifactVersion, bool, error) {
+	return a.versions.getOne(id, a.repository.GetVersion)
+}
+
+func (a *Artifacts) ListVersions() ([]entities.ArtifactVersion, error) {
+	return a.versions.listAll(a.repository.ListVersions)
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Autoscalers struct {
+	repository  storage.Autoscalers
+	autoscalers *table[entities.Autoscaler, entities.AutoscalerUID]
+	events      *table[entities.ScaledEvent, entities.ScaledEventUID]
+}
+
+func NewAutoscalers(repository storage.Autoscalers, interceptor Interceptor, size int) *Autoscalers {
+	return &Autoscalers{
+		repository: repository,
+		autoscalers: newGetTable(interceptor, size, func(autoscaler entities.Autoscaler) (string, entities.AutoscalerUID) {
+			return autoscaler.Type, autoscaler.UID
+		}, repository.Get),
+		events: newGetTable(interceptor, size, func(event entities.ScaledEvent) (string, entities.ScaledEventUID) {
+			return event.Type, event.UID
+		}, repository.GetEvent),
+	}
+}
+
+func (a *Autoscalers) Set(autoscaler entities.Autoscaler) error {
+	return a.autoscalers.set(autoscaler, a.repository.Set)
+}
+
+func (a *Autoscalers) SetMany(autoscalers []entities.Autoscaler) error {
+	return a.autoscalers.setMany(autoscalers, a.repository.SetMany)
+}
+
+func (a *Autoscalers) Get(id entities.AutoscalerUID) (*entities.Autoscaler, bool, error) {
+	return a.autoscalers.getOne(id, a.repository.Get)
+}
+
+func (a *Autoscalers) List() ([]entities.Autoscaler, error) {
+	return a.autoscalers.listAll(a.repository.List)
+}
+
+func (a *Autoscalers) SetEvent(event entities.ScaledEvent) error {
+	return a.events.set(event, a.repository.SetEvent)
+}
+
+func (a *Autoscalers) SetManyEvents(events []entities.ScaledEvent) error {
+	return a.events.setMany(events, a.repository.SetManyEvents)
+}
+
+func (a *Autoscalers) GetEvent(id entities.ScaledEventUID) (*entities.ScaledEvent, bool, error) {
+	return a.events.getOne(id, a.repository.GetEvent)
+}
+
+func (a *Autoscalers) ListEvents() ([]entities.ScaledEvent, error) {
+	return a.events.listAll(a.repository.ListEvents)
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Clusters struct {
+	repository storage.Clusters
+	clusters   *table[entities.Cluster, entities.ClusterUID]
+}
+
+func NewClusters(repository storage.Clusters, interceptor Interceptor, size int) *Clusters {
+	return &Clusters{
+		repository: repository,
+		clusters: newListTable(interceptor, size, func(cluster entities.Cluster) (string, entities.ClusterUID) {
+			return cluster.Type, cluster.UID
+		}, repository.List),
+	}
+}
+
+func (c *Clusters) Set(cluster entities.Cluster) error {
+	return c.clusters.set(cluster, c.repository.Set)
+}
+
+func (c *Clusters) SetMany(clusters []entities.Cluster) error {
+	return c.clusters.setMany(clusters, c.repository.SetMany)
+}
+
+func (c *Clusters) List() ([]entities.Cluster, error) {
+	return c.clusters.listAll(c.repository.List)
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Configurations struct {
+	repository storage.Configurations
+	artifacts  *table[entities.ArtifactConfiguration, entities.ArtifactConfigurationUID]
+	runtimes   *table[entities.RuntimeConfiguration, entities.RuntimeConfigurationUID]
+	nodes      *table[entities.NodeConfiguration, entities.NodeConfigurationUID]
+}
+
+func NewConfigurations(repository storage.Configurations, interceptor Interceptor, size int) *Configurations {
+	return &Configurations{
+		repository: repository,
+		artifacts: newListTable(interceptor, size, func(config entities.ArtifactConfiguration) (string, entities.ArtifactConfigurationUID) {
+			return config.Type, config.UID
+		}, repository.ListArtifacts),
+		runtimes: newListTable(interceptor, size, func(config entities.RuntimeConfiguration) (string, entities.RuntimeConfigurationUID) {
+			return config.Type, config.UID
+		}, repository.ListRuntimes),
+		nodes: newListTable(interceptor, size, func(config entities.NodeConfiguration) (string, entities.NodeConfigurationUID) {
+			return config.Type, config.UID
+		}, repository.ListNodes),
+	}
+}
+
+func (c *Configurations) SetArtifact(config entities.ArtifactConfiguration) error {
+	return c.artifacts.set(config, c.repository.SetArtifact)
+}
+
+func (c *Configurations) SetManyArtifacts(configs []entities.ArtifactConfiguration) error {
+	return c.artifacts.setMany(configs, c.repository.SetManyArtifacts)
+}
+
+func (c *Configurations) ListArtifacts() ([]entities.ArtifactConfiguration, error) {
+	return c.artifacts.listAll(c.repository.ListArtifacts)
+}
+
+func (c *Configurations) SetRuntime(config entities.RuntimeConfiguration) error {
+	return c.runtimes.set(config, c.repository.SetRuntime)
+}
+
+func (c *Configurations) SetManyRuntimes(configs []entities.RuntimeConfiguration) error {
+	return c.runtimes.setMany(configs, c.repository.SetManyRuntimes)
+}
+
+func (c *Configurations) ListRuntimes() ([]entities.RuntimeConfiguration, error) {
+	return c.runtimes.listAll(c.repository.ListRuntimes)
+}
+
+func (c *Configurations) SetNode(config entities.NodeConfiguration) error {
+	return c.nodes.set(config, c.repository.SetNode)
+}
+
+func (c *Configurations) SetManyNodes(configs []entities.NodeConfiguration) error {
+	return c.nodes.setMany(configs, c.repository.SetManyNodes)
+}
+
+func (c *Configurations) ListNodes() ([]entities.NodeConfiguration, error) {
+	return c.nodes.listAll(c.repository.ListNodes)
+}
+
+func (c *Configurations) ListNodesOf(node entities.NodeUID) ([]entities.NodeConfiguration, error) {
+	return c.nodes.listMatching(func() ([]entities.NodeConfiguration, error) {
+		return c.repository.ListNodesOf(node)
+	}, func(config entities.NodeConfiguration) bool {
+		return config.Links.ConfigurationOfNodeUID == node
+	})
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"sort"
+	"sync"
+)
+
+// Outcome is the effect a write had on a stored entity
+type Outcome int
+
+const (
+	Unchanged Outcome = iota
+	Updated
+	Created
+)
+
+// Counts is the number of entities of a type for each outcome
+type Counts struct {
+	Type      string
+	Created   int
+	Updated   int
+	Unchanged int
+}
+
+// Counter is an Interceptor that records the outcome of the writes to each entity
+type Counter struct {
+	write    bool
+	lock     sync.Mutex
+	outcomes map[string]map[string]Outcome
+}
+
+// NewCounter creates a Counter that lets the entities be written if write is true, or only compares them to the stored entities otherwise
+func NewCounter(write bool) *Counter {
+	return &Counter{
+		write:    write,
+		outcomes: map[string]map[string]Outcome{},
+	}
+}
+
+func (c *Counter) Intercept(entityType, uid string, previous, next any) (bool, error) {
+	outcome := Created
+	if previous != nil {
+		changed, err := ChangedFields(previous, next)
+		if err != nil {
+			return false, err
+		}
+
+		outcome = Unchanged
+		if len(changed) > 0 {
+			outcome = Updated
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	outcomes, ok := c.outcomes[entityType]
+	if !ok {
+		outcomes = map[string]Outcome{}
+		c.outcomes[entityType] = outcomes
+	}
+
+	// an entity can be written multiple times, so the outcome is the largest effect of the writes
+	if current, ok := outcomes[uid]; !ok || outcome > current {
+		outcomes[uid] = outcome
+	}
+
+	return c.write, nil
+}
+
+// Counts returns the number of entities for each outcome, sorted by type
+func (c *Counter) Counts() []Counts {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var counts []Counts
+	for entityType, outcomes := range c.outcomes {
+		count := Counts{Type: entityType}
+		for _, outcome := range outcomes {
+			switch outcome {
+			case Created:
+				count.Created++
+			case Updated:
+				count.Updated++
+			default:
+				count.Unchanged++
+			}
+		}
+		counts = append(counts, count)
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].Type < counts[j].Type
+	})
+	return counts
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Customers struct {
+	repository storage.Customers
+	customers  *table[entities.Customer, entities.CustomerUID]
+	names      *table[entities.CustomerName, entities.CustomerNameUID]
+}
+
+func NewCustomers(repository storage.Customers, interceptor Interceptor, size int) *Customers {
+	return &Customers{
+		repository: repository,
+		customers: newGetTable(interceptor, size, func(customer entities.Customer) (string, entities.CustomerUID) {
+			return customer.Type, customer.UID
+		}, repository.Get),
+		names: newListTable(interceptor, size, func(name entities.CustomerName) (string, entities.CustomerNameUID) {
+			return name.Type, name.UID
+		}, repository.ListNames),
+	}
+}
+
+func (c *Customers) Set(customer entities.Customer) error {
+	return c.customers.set(customer, c.repository.Set)
+}
+
+func (c *Customers) SetMany(customers []entities.Customer) error {
+	return c.customers.setMany(customers, c.repository.SetMany)
+}
+
+func (c *Customers) Get(id entities.CustomerUID) (*entities.Customer, bool, error) {
+	return c.customers.getOne(id, c.repository.Get)
+}
+
+func (c *Customers) List() ([]entities.Customer, error) {
+	return c.customers.listAll(c.repository.List)
+}
+
+func (c *Customers) SetName(name entities.CustomerName) error {
+	return c.names.set(name, c.repository.SetName)
+}
+
+func (c *Customers) SetManyNames(names []entities.CustomerName) error {
+	return c.names.setMany(names, c.repository.SetManyNames)
+}
+
+func (c *Customers) GetCurrentName(id entities.CustomerUID) (*entities.CustomerName, bool, error) {
+	names, err := c.names.listMatching(func() ([]entities.CustomerName, error) {
+		name, exists, err := c.repository.GetCurrentName(id)
+		if err != nil || !exists {
+			return nil, err
+		}
+		return []entities.CustomerName{*name}, nil
+	}, func(name entities.CustomerName) bool {
+		return name.Links.NameOfCustomerUID == id && name.Properties.To == nil
+	})
+	if err != nil || len(names) == 0 {
+		return nil, false, err
+	}
+	return &names[0], true, nil
+}
+
+func (c *Customers) ListNames() ([]entities.CustomerName, error) {
+	return c.names.listAll(c.repository.ListNames)
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Deployments struct {
+	repository  storage.Deployments
+	deployments *table[entities.Deployment, entities.DeploymentUID]
+	instances   *table[entities.DeploymentInstance, entities.DeploymentInstanceUID]
+}
+
+func NewDeployments(repository storage.Deployments, interceptor Interceptor, size int) *Deployments {
+	return &Deployments{
+		repository: repository,
+		deployments: newGetTable(interceptor, size, func(deployment entities.Deployment) (string, entities.DeploymentUID) {
+			return deployment.Type, deployment.UID
+		}, repository.Get),
+		instances: newGetTable(interceptor, size, func(instance entities.DeploymentInstance) (string, entities.DeploymentInstanceUID) {
+			return instance.Type, instance.UID
+		}, repository.GetInstance),
+	}
+}
+
+func (d *Deployments) Set(deployment entities.Deployment) error {
+	return d.deployments.set(deployment, d.repository.Set)
+}
+
+func (d *Deployments) SetMany(deployments []entities.Deployment) error {
+	return d.deployments.setMany(deployments, d.repository.SetMany)
+}
+
+func (d *Deployments) Get(id entities.DeploymentUID) (*entities.Deployment, bool, error) {
+	return d.deployments.getOne(id, d.repository.Get)
+}
+
+func (d *Deployments) List() ([]entities.Deployment, error) {
+	return d.deployments.listAll(d.repository.List)
+}
+
+func (d *Deployments) SetInstance(instance entities.DeploymentInstance) error {
+	return d.instances.set(instance, d.repository.SetInstance)
+}
+
+func (d *Deployments) SetManyInstances(instances []entities.DeploymentInstance) error {
+	return d.instances.setMany(instances, d.repository.SetManyInstances)
+}
+
+func (d *Deployments) GetInstance(id entities.DeploymentInstanceUID) (*entities.DeploymentInstance, bool, error) {
+	return d.instances.getOne(id, d.repository.GetInstance)
+}
+
+func (d *Deployments) ListInstances() ([]entities.DeploymentInstance, error) {
+	return d.instances.listAll(d.repository.ListInstances)
+}
+
+func (d *Deployments) ListRunningInstances() ([]entities.DeploymentInstance, error) {
+	return d.instances.listMatching(d.repository.ListRunningInstances, func(instance entities.DeploymentInstance) bool {
+		return instance.Properties.Stopped == nil
+	})
+}
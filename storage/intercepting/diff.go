@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangedFields returns the dot-separated paths of the JSON fields that are different in the next entity compared to the previous.
+// If the previous entity is nil, all the fields of the next entity are returned.
+func ChangedFields(previous, next any) ([]string, error) {
+	previousFields, err := flattenFields(previous)
+	if err != nil {
+		return nil, err
+	}
+	nextFields, err := flattenFields(next)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for path, value := range nextFields {
+		if previousValue, ok := previousFields[path]; !ok || !reflect.DeepEqual(previousValue, value) {
+			changed = append(changed, path)
+		}
+	}
+	for path := range previousFields {
+		if _, ok := nextFields[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+func flattenFields(entity any) (map[string]any, error) {
+	fields := map[string]any{}
+	if entity == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	flattenInto(fields, nil, value)
+	return fields, nil
+}
+
+func flattenInto(fields map[string]any, path []string, value any) {
+	object, ok := value.(map[string]any)
+	if !ok || len(object) == 0 {
+		fields[strings.Join(path, ".")] = value
+		return
+	}
+
+	for key, child := range object {
+		flattenInto(fields, append(path[:len(path):len(path)], key), child)
+	}
+}
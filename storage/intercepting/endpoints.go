@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Endpoints struct {
+	repository storage.Endpoints
+	endpoints  *table[entities.Endpoint, entities.EndpointUID]
+}
+
+func NewEndpoints(repository storage.Endpoints, interceptor Interceptor, size int) *Endpoints {
+	return &Endpoints{
+		repository: repository,
+		endpoints: newListTable(interceptor, size, func(endpoint entities.Endpoint) (string, entities.EndpointUID) {
+			return endpoint.Type, endpoint.UID
+		}, repository.List),
+	}
+}
+
+func (e *Endpoints) Set(endpoint entities.Endpoint) error {
+	return e.endpoints.set(endpoint, e.repository.Set)
+}
+
+func (e *Endpoints) SetMany(endpoints []entities.Endpoint) error {
+	return e.endpoints.setMany(endpoints, e.repository.SetMany)
+}
+
+func (e *Endpoints) List() ([]entities.Endpoint, error) {
+	return e.endpoints.listAll(e.repository.List)
+}
+
+func (e *Endpoints) ListExposedIn(environment entities.EnvironmentUID) ([]entities.Endpoint, error) {
+	return e.endpoints.listMatching(func() ([]entities.Endpoint, error) {
+		return e.repository.ListExposedIn(environment)
+	}, func(endpoint entities.Endpoint) bool {
+		return endpoint.Links.ExposedInEnvironmentUID == environment
+	})
+}
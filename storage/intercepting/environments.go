@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Environments struct {
+	repository   storage.Environments
+	environments *table[entities.Environment, entities.EnvironmentUID]
+}
+
+func NewEnvironments(repository storage.Environments, interceptor Interceptor, size int) *Environments {
+	return &Environments{
+		repository: repository,
+		environments: newMergingGetTable(interceptor, size, func(environment entities.Environment) (string, entities.EnvironmentUID) {
+			return environment.Type, environment.UID
+		}, entities.MergeEnvironmentClusters, repository.Get),
+	}
+}
+
+func (e *Environments) Set(environment entities.Environment) error {
+	return e.environments.set(environment, e.repository.Set)
+}
+
+func (e *Environments) SetMany(environments []entities.Environment) error {
+	return e.environments.setMany(environments, e.repository.SetMany)
+}
+
+func (e *Environments) Get(id entities.EnvironmentUID) (*entities.Environment, bool, error) {
+	return e.environments.getOne(id, e.repository.Get)
+}
+
+func (e *Environments) List() ([]entities.Environment, error) {
+	return e.environments.listAll(e.repository.List)
+}
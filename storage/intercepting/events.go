@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Events struct {
+	repository storage.Events
+	events     *table[entities.Event, entities.EventUID]
+}
+
+func NewEvents(repository storage.Events, interceptor Interceptor, size int) *Events {
+	return &Events{
+		repository: repository,
+		events: newGetTable(interceptor, size, func(event entities.Event) (string, entities.EventUID) {
+			return event.Type, event.UID
+		}, repository.Get),
+	}
+}
+
+func (e *Events) Set(event entities.Event) error {
+	return e.events.set(event, e.repository.Set)
+}
+
+func (e *Events) SetMany(events []entities.Event) error {
+	return e.events.setMany(events, e.repository.SetMany)
+}
+
+func (e *Events) Get(id entities.EventUID) (*entities.Event, bool, error) {
+	return e.events.getOne(id, e.repository.Get)
+}
+
+func (e *Events) List() ([]entities.Event, error) {
+	return e.events.listAll(e.repository.List)
+}
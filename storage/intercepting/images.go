@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Images struct {
+	repository storage.Images
+	releases   *table[entities.ImageRelease, entities.ImageReleaseUID]
+}
+
+func NewImages(repository storage.Images, interceptor Interceptor, size int) *Images {
+	return &Images{
+		repository: repository,
+		releases: newGetTable(interceptor, size, func(release entities.ImageRelease) (string, entities.ImageReleaseUID) {
+			return release.Type, release.UID
+		}, repository.GetRelease),
+	}
+}
+
+func (i *Images) SetRelease(release entities.ImageRelease) error {
+	return i.releases.set(release, i.repository.SetRelease)
+}
+
+func (i *Images) SetManyReleases(releases []entities.ImageRelease) error {
+	return i.releases.setMany(releases, i.repository.SetManyReleases)
+}
+
+func (i *Images) GetRelease(id entities.ImageReleaseUID) (*entities.ImageRelease, bool, error) {
+	return i.releases.getOne(id, i.repository.GetRelease)
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/storage"
+)
+
+// Interceptor is called before an entity is written to storage
+type Interceptor interface {
+	// Intercept is called with the type and UID of the entity, the currently stored entity or nil if it does not exist, and the entity to write.
+	// The entity is only written if it returns true. Entities that are not written are still returned when reading through the repositories.
+	Intercept(entityType, uid string, previous, next any) (bool, error)
+}
+
+// InterceptorFunc is an Interceptor implemented by a function
+type InterceptorFunc func(entityType, uid string, previous, next any) (bool, error)
+
+func (f InterceptorFunc) Intercept(entityType, uid string, previous, next any) (bool, error) {
+	return f(entityType, uid, previous, next)
+}
+
+// Wrap creates repositories that call the interceptor before writing to the provided repositories,
+// that keep at most size entities of each kind that were not written so that they can be read back
+func Wrap(repositories *storage.Repositories, interceptor Interceptor, size int) *storage.Repositories {
+	return &storage.Repositories{
+		Clusters:       NewClusters(repositories.Clusters, interceptor, size),
+		Nodes:          NewNodes(repositories.Nodes, interceptor, size),
+		Customers:      NewCustomers(repositories.Customers, interceptor, size),
+		Applications:   NewApplications(repositories.Applications, interceptor, size),
+		Environments:   NewEnvironments(repositories.Environments, interceptor, size),
+		Artifacts:      NewArtifacts(repositories.Artifacts, interceptor, size),
+		Runtimes:       NewRuntimes(repositories.Runtimes, interceptor, size),
+		Deployments:    NewDeployments(repositories.Deployments, interceptor, size),
+		Configurations: NewConfigurations(repositories.Configurations, interceptor, size),
+		Events:         NewEvents(repositories.Events, interceptor, size),
+		Usages:         NewUsages(repositories.Usages, interceptor, size),
+		Images:         NewImages(repositories.Images, interceptor, size),
+		Endpoints:      NewEndpoints(repositories.Endpoints, interceptor, size),
+		Autoscalers:    NewAutoscalers(repositories.Autoscalers, interceptor, size),
+	}
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"github.com/rs/zerolog"
+)
+
+// DiffLogger is an Interceptor that logs the changed fields of the entities instead of writing them
+type DiffLogger struct {
+	logger zerolog.Logger
+}
+
+func NewDiffLogger(logger zerolog.Logger) *DiffLogger {
+	return &DiffLogger{
+		logger: logger,
+	}
+}
+
+func (d *DiffLogger) Intercept(entityType, uid string, previous, next any) (bool, error) {
+	changed, err := ChangedFields(previous, next)
+	if err != nil {
+		return false, err
+	}
+
+	if len(changed) == 0 {
+		d.logger.Debug().Str("type", entityType).Str("uid", uid).Msg("Entity is unchanged")
+		return false, nil
+	}
+
+	if previous == nil {
+		d.logger.Info().Str("type", entityType).Str("uid", uid).Strs("fields", changed).Msg("Would create entity")
+		return false, nil
+	}
+
+	d.logger.Info().Str("type", entityType).Str("uid", uid).Strs("fields", changed).Msg("Would update entity")
+	return false, nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Nodes struct {
+	repository storage.Nodes
+	nodes      *table[entities.Node, entities.NodeUID]
+	events     *table[entities.NodeEvent, entities.NodeEventUID]
+	pools      *table[entities.NodePool, entities.NodePoolUID]
+}
+
+func NewNodes(repository storage.Nodes, interceptor Interceptor, size int) *Nodes {
+	return &Nodes{
+		repository: repository,
+		nodes: newGetTable(interceptor, size, func(node entities.Node) (string, entities.NodeUID) {
+			return node.Type, node.UID
+		}, repository.Get),
+		events: newListTable(interceptor, size, func(event entities.NodeEvent) (string, entities.NodeEventUID) {
+			return event.Type, event.UID
+		}, repository.ListEvents),
+		pools: newListTable(interceptor, size, func(pool entities.NodePool) (string, entities.NodePoolUID) {
+			return pool.Type, pool.UID
+		}, repository.ListPools),
+	}
+}
+
+func (n *Nodes) Set(node entities.Node) error {
+	return n.nodes.set(node, n.repository.Set)
+}
+
+func (n *Nodes) SetMany(nodes []entities.Node) error {
+	return n.nodes.setMany(nodes, n.repository.SetMany)
+}
+
+func (n *Nodes) Get(id entities.NodeUID) (*entities.Node, bool, error) {
+	return n.nodes.getOne(id, n.repository.Get)
+}
+
+func (n *Nodes) List() ([]entities.Node, error) {
+	return n.nodes.listAll(n.repository.List)
+}
+
+func (n *Nodes) SetEvent(event entities.NodeEvent) error {
+	return n.events.set(event, n.repository.SetEvent)
+}
+
+func (n *Nodes) SetManyEvents(events []entities.NodeEvent) error {
+	return n.events.setMany(events, n.repository.SetManyEvents)
+}
+
+func (n *Nodes) ListEvents() ([]entities.NodeEvent, error) {
+	return n.events.listAll(n.repository.ListEvents)
+}
+
+func (n *Nodes) ListOngoingEvents(id entities.NodeUID) ([]entities.NodeEvent, error) {
+	return n.events.listMatching(func() ([]entities.NodeEvent, error) {
+		return n.repository.ListOngoingEvents(id)
+	}, func(event entities.NodeEvent) bool {
+		return event.Links.HappenedToNodeUID == id && event.Properties.Ended == nil
+	})
+}
+
+func (n *Nodes) SetPool(pool entities.NodePool) error {
+	return n.pools.set(pool, n.repository.SetPool)
+}
+
+func (n *Nodes) SetManyPools(pools []entities.NodePool) error {
+	return n.pools.setMany(pools, n.repository.SetManyPools)
+}
+
+func (n *Nodes) ListPools() ([]entities.NodePool, error) {
+	return n.pools.listAll(n.repository.ListPools)
+}
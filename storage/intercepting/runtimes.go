@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Runtimes struct {
+	repository storage.Runtimes
+	versions   *table[entities.RuntimeVersion, entities.RuntimeVersionUID]
+}
+
+func NewRuntimes(repository storage.Runtimes, interceptor Interceptor, size int) *Runtimes {
+	return &Runtimes{
+		repository: repository,
+		versions: newListTable(interceptor, size, func(version entities.RuntimeVersion) (string, entities.RuntimeVersionUID) {
+			return version.Type, version.UID
+		}, repository.ListVersions),
+	}
+}
+
+func (r *Runtimes) SetVersion(version entities.RuntimeVersion) error {
+	return r.versions.set(version, r.repository.SetVersion)
+}
+
+func (r *Runtimes) SetManyVersions(versions []entities.RuntimeVersion) error {
+	return r.versions.setMany(versions, r.repository.SetManyVersions)
+}
+
+func (r *Runtimes) ListVersions() ([]entities.RuntimeVersion, error) {
+	return r.versions.listAll(r.repository.ListVersions)
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"container/list"
+	"sync"
+)
+
+// table intercepts the writes of a single kind of entity, and keeps the entities that were not written so that they can be read back.
+// At most size entities are kept, and when there are more the entities that were skipped the longest ago are forgotten,
+// so reading them back returns the stored entity again.
+type table[T any, U ~string] struct {
+	interceptor Interceptor
+	identify    func(T) (string, U)
+	merge       func(T, T) T
+	get         func(U) (*T, bool, error)
+	list        func() ([]T, error)
+	size        int
+
+	lock    sync.Mutex
+	loaded  map[U]T
+	skipped map[U]*list.Element
+	order   *list.List
+}
+
+type skippedEntity[T any, U ~string] struct {
+	uid    U
+	entity T
+}
+
+// newGetTable creates a table that finds the previous entity using the get function of the repository
+func newGetTable[T any, U ~string](interceptor Interceptor, size int, identify func(T) (string, U), get func(U) (*T, bool, error)) *table[T, U] {
+	return &table[T, U]{
+		interceptor: interceptor,
+		identify:    identify,
+		get:         get,
+		size:        size,
+		skipped:     map[U]*list.Element{},
+		order:       list.New(),
+	}
+}
+
+// newMergingGetTable creates a table like newGetTable, for entities where the repository merges the written value with the stored value
+func newMergingGetTable[T any, U ~string](interceptor Interceptor, size int, identify func(T) (string, U), merge func(T, T) T, get func(U) (*T, bool, error)) *table[T, U] {
+	t := newGetTable(interceptor, size, identify, get)
+	t.merge = merge
+	return t
+}
+
+// newListTable creates a table that finds the previous entity by listing all the entities of the repository once
+func newListTable[T any, U ~string](interceptor Interceptor, size int, identify func(T) (string, U), listEntities func() ([]T, error)) *table[T, U] {
+	return &table[T, U]{
+		interceptor: interceptor,
+		identify:    identify,
+		list:        listEntities,
+		size:        size,
+		skipped:     map[U]*list.Element{},
+		order:       list.New(),
+	}
+}
+
+func (t *table[T, U]) set(entity T, write func(T) error) error {
+	shouldWrite, err := t.intercept(entity)
+	if err != nil || !shouldWrite {
+		return err
+	}
+	return write(entity)
+}
+
+func (t *table[T, U]) setMany(entities []T, write func([]T) error) error {
+	var written []T
+	for _, entity := range entities {
+		shouldWrite, err := t.intercept(entity)
+		if err != nil {
+			return err
+		}
+		if shouldWrite {
+			written = append(written, entity)
+		}
+	}
+
+	if len(written) == 0 {
+		return nil
+	}
+	return write(written)
+}
+
+// intercept calls the interceptor with the previous entity, and keeps the entity if it should not be written
+func (t *table[T, U]) intercept(entity T) (bool, error) {
+	entityType, uid := t.identify(entity)
+
+	previous, err := t.previous(uid)
+	if err != nil {
+		return false, err
+	}
+	if previous != nil && t.merge != nil {
+		entity = t.merge(previous.(T), entity)
+	}
+
+	shouldWrite, err := t.interceptor.Intercept(entityType, string(uid), previous, entity)
+	if err != nil {
+		return false, err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.loaded != nil {
+		t.loaded[uid] = entity
+	}
+	if shouldWrite {
+		t.forgetSkipped(uid)
+	} else {
+		t.keepSkipped(uid, entity)
+	}
+	return shouldWrite, nil
+}
+
+// keepSkipped keeps the entity that was not written, and forgets the entities that were skipped the longest ago if there are more than size.
+// It must be called while holding the lock.
+func (t *table[T, U]) keepSkipped(uid U, entity T) {
+	t.forgetSkipped(uid)
+	t.skipped[uid] = t.order.PushFront(skippedEntity[T, U]{uid: uid, entity: entity})
+
+	for t.order.Len() > t.size {
+		oldest := t.order.Back()
+		t.order.Remove(oldest)
+		delete(t.skipped, oldest.Value.(skippedEntity[T, U]).uid)
+	}
+}
+
+// forgetSkipped forgets the entity that was not written, if it is kept. It must be called while holding the lock.
+func (t *table[T, U]) forgetSkipped(uid U) {
+	if element, ok := t.skipped[uid]; ok {
+		t.order.Remove(element)
+		delete(t.skipped, uid)
+	}
+}
+
+// getSkipped returns the entity that was not written, if it is kept. It must be called while holding the lock.
+func (t *table[T, U]) getSkipped(uid U) (T, bool) {
+	if element, ok := t.skipped[uid]; ok {
+		return element.Value.(skippedEntity[T, U]).entity, true
+	}
+	var none T
+	return none, false
+}
+
+// previous returns the currently stored entity, or nil if it does not exist
+func (t *table[T, U]) previous(uid U) (any, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if entity, ok := t.getSkipped(uid); ok {
+		return entity, nil
+	}
+
+	if t.get != nil {
+		entity, exists, err := t.get(uid)
+		if err != nil || !exists {
+			return nil, err
+		}
+		return *entity, nil
+	}
+
+	if t.loaded == nil {
+		entities, err := t.list()
+		if err != nil {
+			return nil, err
+		}
+
+		t.loaded = map[U]T{}
+		for _, entity := range entities {
+			_, uid := t.identify(entity)
+			t.loaded[uid] = entity
+		}
+	}
+
+	if entity, ok := t.loaded[uid]; ok {
+		return entity, nil
+	}
+	return nil, nil
+}
+
+// getOne returns the skipped entity with the UID if there is one, or the stored entity otherwise
+func (t *table[T, U]) getOne(uid U, get func(U) (*T, bool, error)) (*T, bool, error) {
+	t.lock.Lock()
+	entity, ok := t.getSkipped(uid)
+	t.lock.Unlock()
+
+	if ok {
+		return &entity, true, nil
+	}
+	return get(uid)
+}
+
+// listMatching returns the stored entities that match, where stored entities are replaced by the skipped entities with the same UID
+func (t *table[T, U]) listMatching(list func() ([]T, error), matches func(T) bool) ([]T, error) {
+	stored, err := list()
+	if err != nil {
+		return nil, err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.skipped) == 0 {
+		return stored, nil
+	}
+
+	var result []T
+	seen := map[U]bool{}
+	for _, entity := range stored {
+		_, uid := t.identify(entity)
+		seen[uid] = true
+		if skipped, ok := t.getSkipped(uid); ok {
+			entity = skipped
+		}
+		if matches(entity) {
+			result = append(result, entity)
+		}
+	}
+	for element := t.order.Front(); element != nil; element = element.Next() {
+		skipped := element.Value.(skippedEntity[T, U])
+		if !seen[skipped.uid] && matches(skipped.entity) {
+			result = append(result, skipped.entity)
+		}
+	}
+	return result, nil
+}
+
+// listAll returns all the stored entities, where stored entities are replaced by the skipped entities with the same UID
+func (t *table[T, U]) listAll(list func() ([]T, error)) ([]T, error) {
+	return t.listMatching(list, func(T) bool { return true })
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package intercepting
+
+import (
+	"dolittle.io/fleet-observer/entities"
+	"dolittle.io/fleet-observer/storage"
+)
+
+type Usages struct {
+	repository storage.Usages
+	usages     *table[entities.ResourceUsage, entities.ResourceUsageUID]
+}
+
+func NewUsages(repository storage.Usages, interceptor Interceptor, size int) *Usages {
+	return &Usages{
+		repository: repository,
+		usages: newListTable(interceptor, size, func(usage entities.ResourceUsage) (string, entities.ResourceUsageUID) {
+			return usage.Type, usage.UID
+		}, repository.List),
+	}
+}
+
+func (u *Usages) Set(usage entities.ResourceUsage) error {
+	return u.usages.set(usage, u.repository.Set)
+}
+
+func (u *Usages) SetMany(usages []entities.ResourceUsage) error {
+	return u.usages.setMany(usages, u.repository.SetMany)
+}
+
+func (u *Usages) List() ([]entities.ResourceUsage, error) {
+	return u.usages.listAll(u.repository.List)
+}
@@ -14,14 +14,16 @@ import (
 )
 
 type Applications struct {
-	collection *mongo.Collection
-	ctx        context.Context
+	collection      *mongo.Collection
+	namesCollection *mongo.Collection
+	ctx             context.Context
 }
 
 func NewApplications(database *mongo.Database, ctx context.Context) *Applications {
 	return &Applications{
-		collection: database.Collection("applications"),
-		ctx:        ctx,
+		collection:      database.Collection("applications"),
+		namesCollection: database.Collection("application-names"),
+		ctx:             ctx,
 	}
 }
 
@@ -31,6 +33,14 @@ func (a *Applications) Set(application entities.Application) error {
 	return err
 }
 
+func (a *Applications) SetMany(applications []entities.Application) error {
+	models := make([]mongo.WriteModel, 0, len(applications))
+	for _, application := range applications {
+		models = append(models, newUpsertModel(application.UID, application))
+	}
+	return bulkWrite(a.collection, a.ctx, models)
+}
+
 func (a *Applications) Get(id entities.ApplicationUID) (*entities.Application, bool, error) {
 	result := a.collection.FindOne(a.ctx, bson.D{{"_id", id}})
 	err := result.Err()
@@ -62,3 +72,55 @@ func (a *Applications) List() ([]entities.Application, error) {
 
 	return applications, cursor.Close(a.ctx)
 }
+
+func (a *Applications) SetName(name entities.ApplicationName) error {
+	update := bson.D{{"$set", name}}
+	_, err := a.namesCollection.UpdateByID(a.ctx, name.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (a *Applications) SetManyNames(names []entities.ApplicationName) error {
+	models := make([]mongo.WriteModel, 0, len(names))
+	for _, name := range names {
+		models = append(models, newUpsertModel(name.UID, name))
+	}
+	return bulkWrite(a.namesCollection, a.ctx, models)
+}
+
+func (a *Applications) GetCurrentName(id entities.ApplicationUID) (*entities.ApplicationName, bool, error) {
+	result := a.namesCollection.FindOne(a.ctx, bson.D{
+		{"links.name_of_application_uid", id},
+		{"$or", bson.A{
+			bson.D{{"properties.to", bson.D{{"$exists", false}}}},
+			bson.D{{"properties.to", nil}},
+		}},
+	})
+	err := result.Err()
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+
+	name := &entities.ApplicationName{}
+	err = result.Decode(name)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return name, true, nil
+}
+
+func (a *Applications) ListNames() ([]entities.ApplicationName, error) {
+	cursor, err := a.namesCollection.Find(a.ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []entities.ApplicationName
+	if err := cursor.All(a.ctx, &names); err != nil {
+		return nil, err
+	}
+
+	return names, cursor.Close(a.ctx)
+}
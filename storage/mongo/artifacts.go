@@ -33,6 +33,14 @@ func (a *Artifacts) Set(artifact entities.Artifact) error {
 	return err
 }
 
+func (a *Artifacts) SetMany(artifacts []entities.Artifact) error {
+	models := make([]mongo.WriteModel, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		models = append(models, newUpsertModel(artifact.UID, artifact))
+	}
+	return bulkWrite(a.collection, a.ctx, models)
+}
+
 func (a *Artifacts) List() ([]entities.Artifact, error) {
 	cursor, err := a.collection.Find(a.ctx, bson.D{})
 	if err != nil {
@@ -48,11 +56,75 @@ func (a *Artifacts) List() ([]entities.Artifact, error) {
 }
 
 func (a *Artifacts) SetVersion(version entities.ArtifactVersion) error {
-	update := bson.D{{"$set", version}}
-	_, err := a.versionsCollection.UpdateByID(a.ctx, version.UID, update, options.Update().SetUpsert(true))
+	_, err := a.versionsCollection.UpdateByID(a.ctx, version.UID, newVersionUpdate(version), options.Update().SetUpsert(true))
 	return err
 }
 
+func (a *Artifacts) SetManyVersions(versions []entities.ArtifactVersion) error {
+	models := make([]mongo.WriteModel, 0, len(versions))
+	for _, version := range versions {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{"_id", version.UID}}).
+			SetUpdate(newVersionUpdate(version)).
+			SetUpsert(true))
+	}
+	return bulkWrite(a.versionsCollection, a.ctx, models)
+}
+
+func (a *Artifacts) AddVersionDigest(id entities.ArtifactVersionUID, digest string) ([]string, bool, error) {
+	filter := bson.D{{"_id", id}, {"properties.digests", bson.D{{"$ne", digest}}}}
+	update := bson.A{bson.D{{"$set", bson.D{{"properties.digests", appendMissing(storedDigests, bson.A{digest})}}}}}
+	result := a.versionsCollection.FindOneAndUpdate(a.ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.Before))
+	err := result.Err()
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	previous := &entities.ArtifactVersion{}
+	if err := result.Decode(previous); err != nil {
+		return nil, true, err
+	}
+	return previous.Properties.Digests, true, nil
+}
+
+// storedDigests are the digests of the stored version
+var storedDigests = bson.D{{"$ifNull", bson.A{"$properties.digests", bson.A{}}}}
+
+// newVersionUpdate creates an update pipeline that sets the fields of the version, and adds its digests to the stored digests
+func newVersionUpdate(version entities.ArtifactVersion) bson.A {
+	digests := bson.A{}
+	for _, digest := range version.Properties.Digests {
+		digests = append(digests, digest)
+	}
+	return bson.A{bson.D{{"$set", bson.D{
+		{"_type", version.Type},
+		{"properties.name", version.Properties.Name},
+		{"properties.released", version.Properties.Released},
+		{"properties.digests", appendMissing(storedDigests, digests)},
+		{"links", bson.D{{"$literal", version.Links}}},
+	}}}}
+}
+
+func (a *Artifacts) GetVersion(id entities.ArtifactVersionUID) (*entities.ArtifactVersion, bool, error) {
+	result := a.versionsCollection.FindOne(a.ctx, bson.D{{"_id", id}})
+	err := result.Err()
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+
+	version := &entities.ArtifactVersion{}
+	err = result.Decode(version)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return version, true, nil
+}
+
 func (a *Artifacts) ListVersions() ([]entities.ArtifactVersion, error) {
 	cursor, err := a.versionsCollection.Find(a.ctx, bson.D{})
 	if err != nil {
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package mongo
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Autoscalers struct {
+	collection       *mongo.Collection
+	eventsCollection *mongo.Collection
+	ctx              context.Context
+}
+
+func NewAutoscalers(database *mongo.Database, ctx context.Context) *Autoscalers {
+	return &Autoscalers{
+		collection:       database.Collection("autoscalers"),
+		eventsCollection: database.Collection("scaled-events"),
+		ctx:              ctx,
+	}
+}
+
+func (a *Autoscalers) Set(autoscaler entities.Autoscaler) error {
+	update := bson.D{{"$set", autoscaler}}
+	_, err := a.collection.UpdateByID(a.ctx, autoscaler.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (a *Autoscalers) SetMany(autoscalers []entities.Autoscaler) error {
+	models := make([]mongo.WriteModel, 0, len(autoscalers))
+	for _, autoscaler := range autoscalers {
+		models = append(models, newUpsertModel(autoscaler.UID, autoscaler))
+	}
+	return bulkWrite(a.collection, a.ctx, models)
+}
+
+func (a *Autoscalers) Get(id entities.AutoscalerUID) (*entities.Autoscaler, bool, error) {
+	result := a.collection.FindOne(a.ctx, bson.D{{"_id", id}})
+	err := result.Err()
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+
+	autoscaler := &entities.Autoscaler{}
+	err = result.Decode(autoscaler)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return autoscaler, true, nil
+}
+
+func (a *Autoscalers) List() ([]entities.Autoscaler, error) {
+	cursor, err := a.collection.Find(a.ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+
+	var autoscalers []entities.Autoscaler
+	if err := cursor.All(a.ctx, &autoscalers); err != nil {
+		return nil, err
+	}
+
+	return autoscalers, cursor.Close(a.ctx)
+}
+
+func (a *Autoscalers) SetEvent(event entities.ScaledEvent) error {
+	update := bson.D{{"$set", event}}
+	_, err := a.eventsCollection.UpdateByID(a.ctx, event.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (a *Autoscalers) SetManyEvents(events []entities.ScaledEvent) error {
+	models := make([]mongo.WriteModel, 0, len(events))
+	for _, event := range events {
+		models = append(models, newUpsertModel(event.UID, event))
+	}
+	return bulkWrite(a.eventsCollection, a.ctx, models)
+}
+
+func (a *Autoscalers) GetEvent(id entities.ScaledEventUID) (*entities.ScaledEvent, bool, error) {
+	result := a.eventsCollection.FindOne(a.ctx, bson.D{{"_id", id}})
+	err := result.Err()
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+
+	event := &entities.ScaledEvent{}
+	err = result.Decode(event)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return event, true, nil
+}
+
+func (a *Autoscalers) ListEvents() ([]entities.ScaledEvent, error) {
+	cursor, err := a.eventsCollection.Find(a.ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []entities.ScaledEvent
+	if err := cursor.All(a.ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, cursor.Close(a.ctx)
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package mongo
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newUpsertModel creates a write model that sets the fields of the document with the id, or inserts it if it does not exist
+func newUpsertModel(id, document any) mongo.WriteModel {
+	return mongo.NewUpdateOneModel().
+		SetFilter(bson.D{{"_id", id}}).
+		SetUpdate(bson.D{{"$set", document}}).
+		SetUpsert(true)
+}
+
+// appendMissing returns an aggregation expression that appends the values that are not already in the stored array to the stored array
+func appendMissing(stored any, values bson.A) bson.D {
+	return bson.D{{"$concatArrays", bson.A{
+		stored,
+		bson.D{{"$filter", bson.D{
+			{"input", bson.D{{"$literal", values}}},
+			{"cond", bson.D{{"$not", bson.A{bson.D{{"$in", bson.A{"$$this", stored}}}}}}},
+		}}},
+	}}}
+}
+
+// bulkWrite performs the writes in a single round-trip, and continues with the remaining writes if one of them fails
+func bulkWrite(collection *mongo.Collection, ctx context.Context, models []mongo.WriteModel) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	_, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
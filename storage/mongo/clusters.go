@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package mongo
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Clusters struct {
+	collection *mongo.Collection
+	ctx        context.Context
+}
+
+func NewClusters(database *mongo.Database, ctx context.Context) *Clusters {
+	return &Clusters{
+		collection: database.Collection("clusters"),
+		ctx:        ctx,
+	}
+}
+
+func (c *Clusters) Set(cluster entities.Cluster) error {
+	update := bson.D{{"$set", cluster}}
+	_, err := c.collection.UpdateByID(c.ctx, cluster.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (c *Clusters) SetMany(clusters []entities.Cluster) error {
+	models := make([]mongo.WriteModel, 0, len(clusters))
+	for _, cluster := range clusters {
+		models = append(models, newUpsertModel(cluster.UID, cluster))
+	}
+	return bulkWrite(c.collection, c.ctx, models)
+}
+
+func (c *Clusters) List() ([]entities.Cluster, error) {
+	cursor, err := c.collection.Find(c.ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []entities.Cluster
+	if err := cursor.All(c.ctx, &clusters); err != nil {
+		return nil, err
+	}
+
+	return clusters, cursor.Close(c.ctx)
+}
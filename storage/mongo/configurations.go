@@ -16,6 +16,7 @@ import (
 type Configurations struct {
 	artifactCollection *mongo.Collection
 	runtimeCollection  *mongo.Collection
+	nodeCollection     *mongo.Collection
 	ctx                context.Context
 }
 
@@ -23,6 +24,7 @@ func NewConfigurations(database *mongo.Database, ctx context.Context) *Configura
 	return &Configurations{
 		artifactCollection: database.Collection("artifact-configurations"),
 		runtimeCollection:  database.Collection("runtime-configurations"),
+		nodeCollection:     database.Collection("node-configurations"),
 		ctx:                ctx,
 	}
 }
@@ -33,6 +35,14 @@ func (c *Configurations) SetArtifact(config entities.ArtifactConfiguration) erro
 	return err
 }
 
+func (c *Configurations) SetManyArtifacts(configs []entities.ArtifactConfiguration) error {
+	models := make([]mongo.WriteModel, 0, len(configs))
+	for _, config := range configs {
+		models = append(models, newUpsertModel(config.UID, config))
+	}
+	return bulkWrite(c.artifactCollection, c.ctx, models)
+}
+
 func (c *Configurations) ListArtifacts() ([]entities.ArtifactConfiguration, error) {
 	cursor, err := c.artifactCollection.Find(c.ctx, bson.D{})
 	if err != nil {
@@ -53,6 +63,14 @@ func (c *Configurations) SetRuntime(config entities.RuntimeConfiguration) error
 	return err
 }
 
+func (c *Configurations) SetManyRuntimes(configs []entities.RuntimeConfiguration) error {
+	models := make([]mongo.WriteModel, 0, len(configs))
+	for _, config := range configs {
+		models = append(models, newUpsertModel(config.UID, config))
+	}
+	return bulkWrite(c.runtimeCollection, c.ctx, models)
+}
+
 func (c *Configurations) ListRuntimes() ([]entities.RuntimeConfiguration, error) {
 	cursor, err := c.runtimeCollection.Find(c.ctx, bson.D{})
 	if err != nil {
@@ -66,3 +84,39 @@ func (c *Configurations) ListRuntimes() ([]entities.RuntimeConfiguration, error)
 
 	return configurations, cursor.Close(c.ctx)
 }
+
+func (c *Configurations) SetNode(config entities.NodeConfiguration) error {
+	update := bson.D{{"$set", config}}
+	_, err := c.nodeCollection.UpdateByID(c.ctx, config.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (c *Configurations) SetManyNodes(configs []entities.NodeConfiguration) error {
+	models := make([]mongo.WriteModel, 0, len(configs))
+	for _, config := range configs {
+		models = append(models, newUpsertModel(config.UID, config))
+	}
+	return bulkWrite(c.nodeCollection, c.ctx, models)
+}
+
+func (c *Configurations) ListNodes() ([]entities.NodeConfiguration, error) {
+	return c.findNodes(bson.D{})
+}
+
+func (c *Configurations) ListNodesOf(node entities.NodeUID) ([]entities.NodeConfiguration, error) {
+	return c.findNodes(bson.D{{"links.configuration_of_node_uid", node}})
+}
+
+func (c *Configurations) findNodes(filter bson.D) ([]entities.NodeConfiguration, error) {
+	cursor, err := c.nodeCollection.Find(c.ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var configurations []entities.NodeConfiguration
+	if err := cursor.All(c.ctx, &configurations); err != nil {
+		return nil, err
+	}
+
+	return configurations, cursor.Close(c.ctx)
+}
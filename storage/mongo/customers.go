@@ -14,14 +14,16 @@ import (
 )
 
 type Customers struct {
-	collection *mongo.Collection
-	ctx        context.Context
+	collection      *mongo.Collection
+	namesCollection *mongo.Collection
+	ctx             context.Context
 }
 
 func NewCustomers(database *mongo.Database, ctx context.Context) *Customers {
 	return &Customers{
-		collection: database.Collection("customers"),
-		ctx:        ctx,
+		collection:      database.Collection("customers"),
+		namesCollection: database.Collection("customer-names"),
+		ctx:             ctx,
 	}
 }
 
@@ -31,6 +33,14 @@ func (c *Customers) Set(customer entities.Customer) error {
 	return err
 }
 
+func (c *Customers) SetMany(customers []entities.Customer) error {
+	models := make([]mongo.WriteModel, 0, len(customers))
+	for _, customer := range customers {
+		models = append(models, newUpsertModel(customer.UID, customer))
+	}
+	return bulkWrite(c.collection, c.ctx, models)
+}
+
 func (c *Customers) List() ([]entities.Customer, error) {
 	cursor, err := c.collection.Find(c.ctx, bson.D{})
 	if err != nil {
@@ -44,3 +54,73 @@ func (c *Customers) List() ([]entities.Customer, error) {
 
 	return customers, cursor.Close(c.ctx)
 }
+
+func (c *Customers) Get(id entities.CustomerUID) (*entities.Customer, bool, error) {
+	result := c.collection.FindOne(c.ctx, bson.D{{"_id", id}})
+	err := result.Err()
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+
+	customer := &entities.Customer{}
+	err = result.Decode(customer)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return customer, true, nil
+}
+
+func (c *Customers) SetName(name entities.CustomerName) error {
+	update := bson.D{{"$set", name}}
+	_, err := c.namesCollection.UpdateByID(c.ctx, name.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (c *Customers) SetManyNames(names []entities.CustomerName) error {
+	models := make([]mongo.WriteModel, 0, len(names))
+	for _, name := range names {
+		models = append(models, newUpsertModel(name.UID, name))
+	}
+	return bulkWrite(c.namesCollection, c.ctx, models)
+}
+
+func (c *Customers) GetCurrentName(id entities.CustomerUID) (*entities.CustomerName, bool, error) {
+	result := c.namesCollection.FindOne(c.ctx, bson.D{
+		{"links.name_of_customer_uid", id},
+		{"$or", bson.A{
+			bson.D{{"properties.to", bson.D{{"$exists", false}}}},
+			bson.D{{"properties.to", nil}},
+		}},
+	})
+	err := result.Err()
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+
+	name := &entities.CustomerName{}
+	err = result.Decode(name)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return name, true, nil
+}
+
+func (c *Customers) ListNames() ([]entities.CustomerName, error) {
+	cursor, err := c.namesCollection.Find(c.ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []entities.CustomerName
+	if err := cursor.All(c.ctx, &names); err != nil {
+		return nil, err
+	}
+
+	return names, cursor.Close(c.ctx)
+}
@@ -33,6 +33,14 @@ func (d *Deployments) Set(deployment entities.Deployment) error {
 	return err
 }
 
+func (d *Deployments) SetMany(deployments []entities.Deployment) error {
+	models := make([]mongo.WriteModel, 0, len(deployments))
+	for _, deployment := range deployments {
+		models = append(models, newUpsertModel(deployment.UID, deployment))
+	}
+	return bulkWrite(d.collection, d.ctx, models)
+}
+
 func (d *Deployments) Get(id entities.DeploymentUID) (*entities.Deployment, bool, error) {
 	result := d.collection.FindOne(d.ctx, bson.D{{"_id", id}})
 	err := result.Err()
@@ -71,6 +79,14 @@ func (d *Deployments) SetInstance(instance entities.DeploymentInstance) error {
 	return err
 }
 
+func (d *Deployments) SetManyInstances(instances []entities.DeploymentInstance) error {
+	models := make([]mongo.WriteModel, 0, len(instances))
+	for _, instance := range instances {
+		models = append(models, newUpsertModel(instance.UID, instance))
+	}
+	return bulkWrite(d.instancesCollection, d.ctx, models)
+}
+
 func (d *Deployments) GetInstance(id entities.DeploymentInstanceUID) (*entities.DeploymentInstance, bool, error) {
 	result := d.instancesCollection.FindOne(d.ctx, bson.D{{"_id", id}})
 	err := result.Err()
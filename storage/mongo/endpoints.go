@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package mongo
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Endpoints struct {
+	collection *mongo.Collection
+	ctx        context.Context
+}
+
+func NewEndpoints(database *mongo.Database, ctx context.Context) *Endpoints {
+	return &Endpoints{
+		collection: database.Collection("endpoints"),
+		ctx:        ctx,
+	}
+}
+
+func (e *Endpoints) Set(endpoint entities.Endpoint) error {
+	update := bson.D{{"$set", endpoint}}
+	_, err := e.collection.UpdateByID(e.ctx, endpoint.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (e *Endpoints) SetMany(endpoints []entities.Endpoint) error {
+	models := make([]mongo.WriteModel, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		models = append(models, newUpsertModel(endpoint.UID, endpoint))
+	}
+	return bulkWrite(e.collection, e.ctx, models)
+}
+
+func (e *Endpoints) List() ([]entities.Endpoint, error) {
+	return e.find(bson.D{})
+}
+
+func (e *Endpoints) ListExposedIn(environment entities.EnvironmentUID) ([]entities.Endpoint, error) {
+	return e.find(bson.D{{"links.exposed_in_environment_uid", environment}})
+}
+
+func (e *Endpoints) find(filter bson.D) ([]entities.Endpoint, error) {
+	cursor, err := e.collection.Find(e.ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []entities.Endpoint
+	if err := cursor.All(e.ctx, &endpoints); err != nil {
+		return nil, err
+	}
+
+	return endpoints, cursor.Close(e.ctx)
+}
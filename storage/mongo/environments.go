@@ -26,11 +26,44 @@ func NewEnvironments(database *mongo.Database, ctx context.Context) *Environment
 }
 
 func (e *Environments) Set(environment entities.Environment) error {
-	update := bson.D{{"$set", environment}}
-	_, err := e.collection.UpdateByID(e.ctx, environment.UID, update, options.Update().SetUpsert(true))
+	_, err := e.collection.UpdateByID(e.ctx, environment.UID, newEnvironmentUpdate(environment), options.Update().SetUpsert(true))
 	return err
 }
 
+func (e *Environments) SetMany(environments []entities.Environment) error {
+	models := make([]mongo.WriteModel, 0, len(environments))
+	for _, environment := range environments {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{"_id", environment.UID}}).
+			SetUpdate(newEnvironmentUpdate(environment)).
+			SetUpsert(true))
+	}
+	return bulkWrite(e.collection, e.ctx, models)
+}
+
+// storedClusters are the clusters of the stored environment, including the single cluster that was stored before environments could run in multiple clusters
+var storedClusters = bson.D{{"$ifNull", bson.A{
+	"$links.runs_in_cluster_uids",
+	bson.D{{"$cond", bson.A{bson.D{{"$ifNull", bson.A{"$links.runs_in_cluster_uid", false}}}, bson.A{"$links.runs_in_cluster_uid"}, bson.A{}}}},
+}}}
+
+// newEnvironmentUpdate creates an update pipeline that sets the fields of the environment, and adds its clusters to the stored clusters
+func newEnvironmentUpdate(environment entities.Environment) bson.A {
+	clusters := bson.A{}
+	for _, cluster := range environment.Links.RunsInClusterUIDs {
+		clusters = append(clusters, cluster)
+	}
+	return bson.A{
+		bson.D{{"$set", bson.D{
+			{"_type", environment.Type},
+			{"properties", bson.D{{"$literal", environment.Properties}}},
+			{"links.environment_of_application_uid", environment.Links.EnvironmentOfApplicationUID},
+			{"links.runs_in_cluster_uids", appendMissing(storedClusters, clusters)},
+		}}},
+		bson.D{{"$unset", "links.runs_in_cluster_uid"}},
+	}
+}
+
 func (e *Environments) Get(id entities.EnvironmentUID) (*entities.Environment, bool, error) {
 	result := e.collection.FindOne(e.ctx, bson.D{{"_id", id}})
 	err := result.Err()
@@ -31,6 +31,14 @@ func (e *Events) Set(event entities.Event) error {
 	return err
 }
 
+func (e *Events) SetMany(events []entities.Event) error {
+	models := make([]mongo.WriteModel, 0, len(events))
+	for _, event := range events {
+		models = append(models, newUpsertModel(event.UID, event))
+	}
+	return bulkWrite(e.collection, e.ctx, models)
+}
+
 func (e *Events) Get(id entities.EventUID) (*entities.Event, bool, error) {
 	result := e.collection.FindOne(e.ctx, bson.D{{"_id", id}})
 	err := result.Err()
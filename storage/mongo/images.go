@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package mongo
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Images struct {
+	releasesCollection *mongo.Collection
+	ctx                context.Context
+}
+
+func NewImages(database *mongo.Database, ctx context.Context) *Images {
+	return &Images{
+		releasesCollection: database.Collection("image-releases"),
+		ctx:                ctx,
+	}
+}
+
+func (i *Images) SetRelease(release entities.ImageRelease) error {
+	update := bson.D{{"$set", release}}
+	_, err := i.releasesCollection.UpdateByID(i.ctx, release.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (i *Images) SetManyReleases(releases []entities.ImageRelease) error {
+	models := make([]mongo.WriteModel, 0, len(releases))
+	for _, release := range releases {
+		models = append(models, newUpsertModel(release.UID, release))
+	}
+	return bulkWrite(i.releasesCollection, i.ctx, models)
+}
+
+func (i *Images) GetRelease(id entities.ImageReleaseUID) (*entities.ImageRelease, bool, error) {
+	result := i.releasesCollection.FindOne(i.ctx, bson.D{{"_id", id}})
+	err := result.Err()
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+
+	release := &entities.ImageRelease{}
+	err = result.Decode(release)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return release, true, nil
+}
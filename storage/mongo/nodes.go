@@ -14,14 +14,18 @@ import (
 )
 
 type Nodes struct {
-	collection *mongo.Collection
-	ctx        context.Context
+	collection       *mongo.Collection
+	eventsCollection *mongo.Collection
+	poolsCollection  *mongo.Collection
+	ctx              context.Context
 }
 
 func NewNodes(database *mongo.Database, ctx context.Context) *Nodes {
 	return &Nodes{
-		collection: database.Collection("nodes"),
-		ctx:        ctx,
+		collection:       database.Collection("nodes"),
+		eventsCollection: database.Collection("node-events"),
+		poolsCollection:  database.Collection("node-pools"),
+		ctx:              ctx,
 	}
 }
 
@@ -31,6 +35,32 @@ func (n *Nodes) Set(node entities.Node) error {
 	return err
 }
 
+func (n *Nodes) SetMany(nodes []entities.Node) error {
+	models := make([]mongo.WriteModel, 0, len(nodes))
+	for _, node := range nodes {
+		models = append(models, newUpsertModel(node.UID, node))
+	}
+	return bulkWrite(n.collection, n.ctx, models)
+}
+
+func (n *Nodes) Get(id entities.NodeUID) (*entities.Node, bool, error) {
+	result := n.collection.FindOne(n.ctx, bson.D{{"_id", id}})
+	err := result.Err()
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+
+	node := &entities.Node{}
+	err = result.Decode(node)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return node, true, nil
+}
+
 func (n *Nodes) List() ([]entities.Node, error) {
 	cursor, err := n.collection.Find(n.ctx, bson.D{})
 	if err != nil {
@@ -44,3 +74,79 @@ func (n *Nodes) List() ([]entities.Node, error) {
 
 	return nodes, cursor.Close(n.ctx)
 }
+
+func (n *Nodes) SetEvent(event entities.NodeEvent) error {
+	update := bson.D{{"$set", event}}
+	_, err := n.eventsCollection.UpdateByID(n.ctx, event.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (n *Nodes) SetManyEvents(events []entities.NodeEvent) error {
+	models := make([]mongo.WriteModel, 0, len(events))
+	for _, event := range events {
+		models = append(models, newUpsertModel(event.UID, event))
+	}
+	return bulkWrite(n.eventsCollection, n.ctx, models)
+}
+
+func (n *Nodes) ListEvents() ([]entities.NodeEvent, error) {
+	cursor, err := n.eventsCollection.Find(n.ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []entities.NodeEvent
+	if err := cursor.All(n.ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, cursor.Close(n.ctx)
+}
+
+func (n *Nodes) ListOngoingEvents(id entities.NodeUID) ([]entities.NodeEvent, error) {
+	cursor, err := n.eventsCollection.Find(n.ctx, bson.D{
+		{"links.happened_to_node_uid", id},
+		{"$or", bson.A{
+			bson.D{{"properties.ended", bson.D{{"$exists", false}}}},
+			bson.D{{"properties.ended", nil}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []entities.NodeEvent
+	if err := cursor.All(n.ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, cursor.Close(n.ctx)
+}
+
+func (n *Nodes) SetPool(pool entities.NodePool) error {
+	update := bson.D{{"$set", pool}}
+	_, err := n.poolsCollection.UpdateByID(n.ctx, pool.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (n *Nodes) SetManyPools(pools []entities.NodePool) error {
+	models := make([]mongo.WriteModel, 0, len(pools))
+	for _, pool := range pools {
+		models = append(models, newUpsertModel(pool.UID, pool))
+	}
+	return bulkWrite(n.poolsCollection, n.ctx, models)
+}
+
+func (n *Nodes) ListPools() ([]entities.NodePool, error) {
+	cursor, err := n.poolsCollection.Find(n.ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+
+	var pools []entities.NodePool
+	if err := cursor.All(n.ctx, &pools); err != nil {
+		return nil, err
+	}
+
+	return pools, cursor.Close(n.ctx)
+}
@@ -31,6 +31,14 @@ func (r *Runtimes) SetVersion(version entities.RuntimeVersion) error {
 	return err
 }
 
+func (r *Runtimes) SetManyVersions(versions []entities.RuntimeVersion) error {
+	models := make([]mongo.WriteModel, 0, len(versions))
+	for _, version := range versions {
+		models = append(models, newUpsertModel(version.UID, version))
+	}
+	return bulkWrite(r.versionsCollection, r.ctx, models)
+}
+
 func (r *Runtimes) ListVersions() ([]entities.RuntimeVersion, error) {
 	cursor, err := r.versionsCollection.Find(r.ctx, bson.D{})
 	if err != nil {
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package mongo
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Usages struct {
+	collection *mongo.Collection
+	ctx        context.Context
+}
+
+func NewUsages(database *mongo.Database, ctx context.Context) *Usages {
+	return &Usages{
+		collection: database.Collection("resource-usages"),
+		ctx:        ctx,
+	}
+}
+
+func (u *Usages) Set(usage entities.ResourceUsage) error {
+	update := bson.D{{"$set", usage}}
+	_, err := u.collection.UpdateByID(u.ctx, usage.UID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (u *Usages) SetMany(usages []entities.ResourceUsage) error {
+	models := make([]mongo.WriteModel, 0, len(usages))
+	for _, usage := range usages {
+		models = append(models, newUpsertModel(usage.UID, usage))
+	}
+	return bulkWrite(u.collection, u.ctx, models)
+}
+
+func (u *Usages) List() ([]entities.ResourceUsage, error) {
+	cursor, err := u.collection.Find(u.ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+
+	var usages []entities.ResourceUsage
+	if err := cursor.All(u.ctx, &usages); err != nil {
+		return nil, err
+	}
+
+	return usages, cursor.Close(u.ctx)
+}
@@ -9,41 +9,58 @@ import (
 	"context"
 	"dolittle.io/fleet-observer/entities"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"time"
 )
 
 type Applications struct {
-	session neo4j.SessionWithContext
-	ctx     context.Context
+	driver neo4j.DriverWithContext
+	ctx    context.Context
 }
 
-func NewApplications(session neo4j.SessionWithContext, ctx context.Context) *Applications {
+func NewApplications(driver neo4j.DriverWithContext, ctx context.Context) *Applications {
 	return &Applications{
-		session: session,
-		ctx:     ctx,
+		driver: driver,
+		ctx:    ctx,
 	}
 }
 
 func (a *Applications) Set(application entities.Application) error {
-	return multiUpdate(
-		a.session,
-		a.ctx,
-		map[string]any{
+	return a.SetMany([]entities.Application{application})
+}
+
+func (a *Applications) SetMany(applications []entities.Application) error {
+	batch := make([]any, 0, len(applications))
+	for _, application := range applications {
+		var deleted any = nil
+		if application.Properties.Deleted != nil {
+			deleted = application.Properties.Deleted.Format(time.RFC3339)
+		}
+		batch = append(batch, map[string]any{
 			"uid":               application.UID,
 			"id":                application.Properties.ID,
 			"name":              application.Properties.Name,
+			"created":           application.Properties.Created.Format(time.RFC3339),
+			"deleted":           deleted,
 			"link_customer_uid": application.Links.OwnedByCustomerUID,
-		},
+		})
+	}
+	return multiUpdateMany(
+		a.driver,
+		a.ctx,
+		batch,
 		`
-			MERGE (application:Application { _uid: $uid })
-			SET application = { _uid: $uid, id: $id, name: $name }
+			UNWIND $batch AS row
+			MERGE (application:Application { _uid: row.uid })
+			SET application = { _uid: row.uid, id: row.id, name: row.name, created: datetime(row.created), deleted: datetime(row.deleted) }
 			RETURN id(application)
 		`, `
-			MATCH (application:Application { _uid: $uid })
-			WITH application
-				MERGE (customer:Customer { _uid: $link_customer_uid})
-				WITH application, customer
+			UNWIND $batch AS row
+			MATCH (application:Application { _uid: row.uid })
+			WITH row, application
+				MERGE (customer:Customer { _uid: row.link_customer_uid})
+				WITH row, application, customer
 					MERGE (application)-[:OwnedBy]->(customer)
-					WITH application, customer
+					WITH row, application, customer
 						MATCH (application)-[r:OwnedBy]->(other)
 						WHERE other._uid <> customer._uid
 						DELETE r
@@ -54,7 +71,7 @@ func (a *Applications) Set(application entities.Application) error {
 func (a *Applications) Get(id entities.ApplicationUID) (*entities.Application, bool, error) {
 	application := &entities.Application{}
 	found, err := findSingleJson(
-		a.session,
+		a.driver,
 		a.ctx,
 		map[string]any{
 			"uid": id,
@@ -66,7 +83,9 @@ func (a *Applications) Get(id entities.ApplicationUID) (*entities.Application, b
 				type: "Application",
 				properties: {
 					id: application.id,
-					name: application.name
+					name: application.name,
+					created: toString(application.created),
+					deleted: toString(application.deleted)
 				},
 				links: {
 					ownedBy: customer._uid
@@ -81,7 +100,7 @@ func (a *Applications) Get(id entities.ApplicationUID) (*entities.Application, b
 func (a *Applications) List() ([]entities.Application, error) {
 	var applications []entities.Application
 	return applications, findAllJson(
-		a.session,
+		a.driver,
 		a.ctx,
 		`
 			MATCH (application:Application)-[:OwnedBy]->(customer:Customer)
@@ -90,7 +109,9 @@ func (a *Applications) List() ([]entities.Application, error) {
 				type: "Application",
 				properties: {
 					id: application.id,
-					name: application.name
+					name: application.name,
+					created: toString(application.created),
+					deleted: toString(application.deleted)
 				},
 				links: {
 					ownedBy: customer._uid
@@ -100,3 +121,100 @@ func (a *Applications) List() ([]entities.Application, error) {
 		`,
 		&applications)
 }
+
+func (a *Applications) SetName(name entities.ApplicationName) error {
+	return a.SetManyNames([]entities.ApplicationName{name})
+}
+
+func (a *Applications) SetManyNames(names []entities.ApplicationName) error {
+	batch := make([]any, 0, len(names))
+	for _, name := range names {
+		var to any = nil
+		if name.Properties.To != nil {
+			to = name.Properties.To.Format(time.RFC3339)
+		}
+		batch = append(batch, map[string]any{
+			"uid":                  name.UID,
+			"name":                 name.Properties.Name,
+			"from":                 name.Properties.From.Format(time.RFC3339),
+			"to":                   to,
+			"link_application_uid": name.Links.NameOfApplicationUID,
+		})
+	}
+	return multiUpdateMany(
+		a.driver,
+		a.ctx,
+		batch,
+		`
+			UNWIND $batch AS row
+			MERGE (name:ApplicationName { _uid: row.uid })
+			SET name = { _uid: row.uid, name: row.name, from: datetime(row.from), to: datetime(row.to) }
+			RETURN id(name)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (name:ApplicationName { _uid: row.uid })
+			WITH row, name
+				MERGE (application:Application { _uid: row.link_application_uid})
+				WITH row, name, application
+					MERGE (name)-[:NameOf]->(application)
+					WITH row, name, application
+						MATCH (name)-[r:NameOf]->(other)
+						WHERE other._uid <> application._uid
+						DELETE r
+			RETURN id(name)
+		`)
+}
+
+func (a *Applications) GetCurrentName(id entities.ApplicationUID) (*entities.ApplicationName, bool, error) {
+	name := &entities.ApplicationName{}
+	found, err := findSingleJson(
+		a.driver,
+		a.ctx,
+		map[string]any{
+			"uid": id,
+		},
+		`
+			MATCH (name:ApplicationName)-[:NameOf]->(application:Application { _uid: $uid })
+			WHERE name.to IS NULL
+			WITH {
+				uid: name._uid,
+				type: "ApplicationName",
+				properties: {
+					name: name.name,
+					from: toString(name.from),
+					to: toString(name.to)
+				},
+				links: {
+					nameOf: application._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(entry) as json
+		`,
+		name)
+	return name, found, err
+}
+
+func (a *Applications) ListNames() ([]entities.ApplicationName, error) {
+	var names []entities.ApplicationName
+	return names, findAllJson(
+		a.driver,
+		a.ctx,
+		`
+			MATCH (name:ApplicationName)-[:NameOf]->(application:Application)
+			WITH {
+				uid: name._uid,
+				type: "ApplicationName",
+				properties: {
+					name: name.name,
+					from: toString(name.from),
+					to: toString(name.to)
+				},
+				links: {
+					nameOf: application._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&names)
+}
@@ -9,41 +9,52 @@ import (
 	"context"
 	"dolittle.io/fleet-observer/entities"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"time"
 )
 
 type Artifacts struct {
-	session neo4j.SessionWithContext
-	ctx     context.Context
+	driver neo4j.DriverWithContext
+	ctx    context.Context
 }
 
-func NewArtifacts(session neo4j.SessionWithContext, ctx context.Context) *Artifacts {
+func NewArtifacts(driver neo4j.DriverWithContext, ctx context.Context) *Artifacts {
 	return &Artifacts{
-		session: session,
-		ctx:     ctx,
+		driver: driver,
+		ctx:    ctx,
 	}
 }
 
 func (a *Artifacts) Set(artifact entities.Artifact) error {
-	return multiUpdate(
-		a.session,
-		a.ctx,
-		map[string]any{
+	return a.SetMany([]entities.Artifact{artifact})
+}
+
+func (a *Artifacts) SetMany(artifacts []entities.Artifact) error {
+	batch := make([]any, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		batch = append(batch, map[string]any{
 			"uid":               artifact.UID,
 			"id":                artifact.Properties.ID,
 			"link_customer_uid": artifact.Links.DevelopedByCustomerUID,
-		},
+		})
+	}
+	return multiUpdateMany(
+		a.driver,
+		a.ctx,
+		batch,
 		`
-			MERGE (artifact:Artifact { _uid: $uid })
-			SET artifact = { _uid: $uid, id: $id }
+			UNWIND $batch AS row
+			MERGE (artifact:Artifact { _uid: row.uid })
+			SET artifact = { _uid: row.uid, id: row.id }
 			RETURN id(artifact)
 		`,
 		`
-			MATCH (artifact:Artifact { _uid: $uid })
-			WITH artifact
-				MERGE (customer:Customer { _uid: $link_customer_uid})
-				WITH artifact, customer
+			UNWIND $batch AS row
+			MATCH (artifact:Artifact { _uid: row.uid })
+			WITH row, artifact
+				MERGE (customer:Customer { _uid: row.link_customer_uid})
+				WITH row, artifact, customer
 					MERGE (artifact)-[:DevelopedBy]->(customer)
-					WITH artifact, customer
+					WITH row, artifact, customer
 						MATCH (artifact)-[r:DevelopedBy]->(other)
 						WHERE other._uid <> customer._uid
 						DELETE r
@@ -54,7 +65,7 @@ func (a *Artifacts) Set(artifact entities.Artifact) error {
 func (a *Artifacts) List() ([]entities.Artifact, error) {
 	var artifacts []entities.Artifact
 	return artifacts, findAllJson(
-		a.session,
+		a.driver,
 		a.ctx,
 		`
 			MATCH (artifact:Artifact)-[:DevelopedBy]->(customer:Customer)
@@ -74,26 +85,50 @@ func (a *Artifacts) List() ([]entities.Artifact, error) {
 }
 
 func (a *Artifacts) SetVersion(version entities.ArtifactVersion) error {
-	return multiUpdate(
-		a.session,
-		a.ctx,
-		map[string]any{
+	return a.SetManyVersions([]entities.ArtifactVersion{version})
+}
+
+func (a *Artifacts) SetManyVersions(versions []entities.ArtifactVersion) error {
+	batch := make([]any, 0, len(versions))
+	for _, version := range versions {
+		var released any = nil
+		if version.Properties.Released != nil {
+			released = version.Properties.Released.Format(time.RFC3339)
+		}
+		batch = append(batch, map[string]any{
 			"uid":               version.UID,
 			"name":              version.Properties.Name,
+			"released":          released,
+			"digests":           version.Properties.Digests,
 			"link_artifact_uid": version.Links.VersionOfArtifactUID,
-		},
+		})
+	}
+	return multiUpdateMany(
+		a.driver,
+		a.ctx,
+		batch,
 		`
-			MERGE (version:ArtifactVersion { _uid: $uid })
-			SET version = { _uid: $uid, name: $name }
+			UNWIND $batch AS row
+			MERGE (version:ArtifactVersion { _uid: row.uid })
+			WITH row, version
+				CALL apoc.lock.nodes([version])
+				WITH row, version, coalesce(version.digests, []) AS stored
+					SET version = {
+						_uid: row.uid,
+						name: row.name,
+						released: datetime(row.released),
+						digests: stored + [digest IN coalesce(row.digests, []) WHERE NOT digest IN stored]
+					}
 			RETURN id(version)
 		`,
 		`
-			MATCH (version:ArtifactVersion { _uid: $uid })
-			WITH version
-				MERGE (artifact:Artifact { _uid: $link_artifact_uid})
-				WITH version, artifact
+			UNWIND $batch AS row
+			MATCH (version:ArtifactVersion { _uid: row.uid })
+			WITH row, version
+				MERGE (artifact:Artifact { _uid: row.link_artifact_uid})
+				WITH row, version, artifact
 					MERGE (version)-[:VersionOf]->(artifact)
-					WITH version, artifact
+					WITH row, version, artifact
 						MATCH (version)-[r:VersionOf]->(other)
 						WHERE other._uid <> artifact._uid
 						DELETE r
@@ -101,10 +136,80 @@ func (a *Artifacts) SetVersion(version entities.ArtifactVersion) error {
 		`)
 }
 
+func (a *Artifacts) AddVersionDigest(id entities.ArtifactVersionUID, digest string) ([]string, bool, error) {
+	session := a.driver.NewSession(a.ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(a.ctx)
+
+	previous, err := session.ExecuteWrite(
+		a.ctx,
+		func(transaction neo4j.ManagedTransaction) (any, error) {
+			result, err := transaction.Run(
+				a.ctx,
+				`
+					MATCH (version:ArtifactVersion { _uid: $uid })
+					CALL apoc.lock.nodes([version])
+					WITH version, coalesce(version.digests, []) AS stored
+					WHERE NOT $digest IN stored
+						SET version.digests = stored + $digest
+					RETURN stored
+				`,
+				map[string]any{
+					"uid":    id,
+					"digest": digest,
+				})
+			if err != nil {
+				return nil, err
+			}
+
+			if !result.Next(a.ctx) {
+				return nil, result.Err()
+			}
+
+			stored, _ := result.Record().Get("stored")
+			digests := []string{}
+			for _, value := range stored.([]any) {
+				digests = append(digests, value.(string))
+			}
+			return digests, nil
+		})
+	if err != nil || previous == nil {
+		return nil, false, err
+	}
+	return previous.([]string), true, nil
+}
+
+func (a *Artifacts) GetVersion(id entities.ArtifactVersionUID) (*entities.ArtifactVersion, bool, error) {
+	version := &entities.ArtifactVersion{}
+	found, err := findSingleJson(
+		a.driver,
+		a.ctx,
+		map[string]any{
+			"uid": id,
+		},
+		`
+			MATCH (version:ArtifactVersion { _uid: $uid })-[:VersionOf]->(artifact:Artifact)
+			WITH {
+				uid: version._uid,
+				type: "ArtifactVersion",
+				properties: {
+					name: version.name,
+					released: toString(version.released),
+					digests: version.digests
+				},
+				links: {
+					versionOf: artifact._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(entry) as json
+		`,
+		version)
+	return version, found, err
+}
+
 func (a *Artifacts) ListVersions() ([]entities.ArtifactVersion, error) {
 	var versions []entities.ArtifactVersion
 	return versions, findAllJson(
-		a.session,
+		a.driver,
 		a.ctx,
 		`
 			MATCH (version:ArtifactVersion)-[:VersionOf]->(artifact:Artifact)
@@ -112,7 +217,9 @@ func (a *Artifacts) ListVersions() ([]entities.ArtifactVersion, error) {
 				uid: version._uid,
 				type: "ArtifactVersion",
 				properties: {
-					name: version.name
+					name: version.name,
+					released: toString(version.released),
+					digests: version.digests
 				},
 				links: {
 					versionOf: artifact._uid
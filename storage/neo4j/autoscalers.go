@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"time"
+)
+
+type Autoscalers struct {
+	driver neo4j.DriverWithContext
+	ctx    context.Context
+}
+
+func NewAutoscalers(driver neo4j.DriverWithContext, ctx context.Context) *Autoscalers {
+	return &Autoscalers{
+		driver: driver,
+		ctx:    ctx,
+	}
+}
+
+func (a *Autoscalers) Set(autoscaler entities.Autoscaler) error {
+	return a.SetMany([]entities.Autoscaler{autoscaler})
+}
+
+func (a *Autoscalers) SetMany(autoscalers []entities.Autoscaler) error {
+	batch := make([]any, 0, len(autoscalers))
+	for _, autoscaler := range autoscalers {
+		var targetCPU, targetMemory any = nil, nil
+		if autoscaler.Properties.TargetCPU != nil {
+			targetCPU = *autoscaler.Properties.TargetCPU
+		}
+		if autoscaler.Properties.TargetMemory != nil {
+			targetMemory = *autoscaler.Properties.TargetMemory
+		}
+		var lastScaled any = nil
+		if autoscaler.Properties.LastScaled != nil {
+			lastScaled = autoscaler.Properties.LastScaled.Format(time.RFC3339)
+		}
+		batch = append(batch, map[string]any{
+			"uid":                 autoscaler.UID,
+			"name":                autoscaler.Properties.Name,
+			"minReplicas":         autoscaler.Properties.MinReplicas,
+			"maxReplicas":         autoscaler.Properties.MaxReplicas,
+			"targetCpu":           targetCPU,
+			"targetMemory":        targetMemory,
+			"replicas":            autoscaler.Properties.Replicas,
+			"lastScaled":          lastScaled,
+			"link_deployment_uid": autoscaler.Links.ScalesDeploymentUID,
+		})
+	}
+	return multiUpdateMany(
+		a.driver,
+		a.ctx,
+		batch,
+		`
+			UNWIND $batch AS row
+			MERGE (autoscaler:Autoscaler { _uid: row.uid })
+			SET autoscaler = {
+				_uid: row.uid,
+				name: row.name,
+				minReplicas: row.minReplicas,
+				maxReplicas: row.maxReplicas,
+				targetCpu: row.targetCpu,
+				targetMemory: row.targetMemory,
+				replicas: row.replicas,
+				lastScaled: datetime(row.lastScaled)
+			}
+			RETURN id(autoscaler)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (autoscaler:Autoscaler { _uid: row.uid })
+			WITH row, autoscaler
+				MERGE (deployment:Deployment { _uid: row.link_deployment_uid })
+				WITH row, autoscaler, deployment
+					MERGE (autoscaler)-[:Scales]->(deployment)
+					WITH row, autoscaler, deployment
+						MATCH (autoscaler)-[r:Scales]->(other)
+						WHERE other._uid <> deployment._uid
+						DELETE r
+			RETURN id(autoscaler)
+		`)
+}
+
+func (a *Autoscalers) Get(id entities.AutoscalerUID) (*entities.Autoscaler, bool, error) {
+	autoscaler := &entities.Autoscaler{}
+	found, err := findSingleJson(
+		a.driver,
+		a.ctx,
+		map[string]any{
+			"uid": id,
+		},
+		`
+			MATCH (autoscaler:Autoscaler { _uid: $uid })-[:Scales]->(deployment:Deployment)
+			WITH {
+				uid: autoscaler._uid,
+				type: "Autoscaler",
+				properties: {
+					name: autoscaler.name,
+					minReplicas: autoscaler.minReplicas,
+					maxReplicas: autoscaler.maxReplicas,
+					targetCpu: autoscaler.targetCpu,
+					targetMemory: autoscaler.targetMemory,
+					replicas: autoscaler.replicas,
+					lastScaled: toString(autoscaler.lastScaled)
+				},
+				links: {
+					scales: deployment._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(entry) as json
+		`,
+		autoscaler)
+	return autoscaler, found, err
+}
+
+func (a *Autoscalers) List() ([]entities.Autoscaler, error) {
+	var autoscalers []entities.Autoscaler
+	return autoscalers, findAllJson(
+		a.driver,
+		a.ctx,
+		`
+			MATCH (autoscaler:Autoscaler)-[:Scales]->(deployment:Deployment)
+			WITH {
+				uid: autoscaler._uid,
+				type: "Autoscaler",
+				properties: {
+					name: autoscaler.name,
+					minReplicas: autoscaler.minReplicas,
+					maxReplicas: autoscaler.maxReplicas,
+					targetCpu: autoscaler.targetCpu,
+					targetMemory: autoscaler.targetMemory,
+					replicas: autoscaler.replicas,
+					lastScaled: toString(autoscaler.lastScaled)
+				},
+				links: {
+					scales: deployment._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&autoscalers)
+}
+
+func (a *Autoscalers) SetEvent(event entities.ScaledEvent) error {
+	return a.SetManyEvents([]entities.ScaledEvent{event})
+}
+
+func (a *Autoscalers) SetManyEvents(events []entities.ScaledEvent) error {
+	batch := make([]any, 0, len(events))
+	for _, event := range events {
+		var from, reason any = nil, nil
+		if event.Properties.From != nil {
+			from = *event.Properties.From
+		}
+		if event.Properties.Reason != "" {
+			reason = event.Properties.Reason
+		}
+		batch = append(batch, map[string]any{
+			"uid":                 event.UID,
+			"time":                event.Properties.Time.Format(time.RFC3339),
+			"from":                from,
+			"to":                  event.Properties.To,
+			"reason":              reason,
+			"link_deployment_uid": event.Links.HappenedToDeploymentUID,
+		})
+	}
+	return multiUpdateMany(
+		a.driver,
+		a.ctx,
+		batch,
+		`
+			UNWIND $batch AS row
+			MERGE (event:ScaledEvent { _uid: row.uid })
+			SET event = {
+				_uid: row.uid,
+				time: datetime(row.time),
+				from: row.from,
+				to: row.to,
+				reason: row.reason
+			}
+			RETURN id(event)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (event:ScaledEvent { _uid: row.uid })
+			WITH row, event
+				MERGE (deployment:Deployment { _uid: row.link_deployment_uid })
+				WITH row, event, deployment
+					MERGE (event)-[:HappenedTo]->(deployment)
+					WITH row, event, deployment
+						MATCH (event)-[r:HappenedTo]->(other)
+						WHERE other._uid <> deployment._uid
+						DELETE r
+			RETURN id(event)
+		`)
+}
+
+func (a *Autoscalers) GetEvent(id entities.ScaledEventUID) (*entities.ScaledEvent, bool, error) {
+	event := &entities.ScaledEvent{}
+	found, err := findSingleJson(
+		a.driver,
+		a.ctx,
+		map[string]any{
+			"uid": id,
+		},
+		`
+			MATCH (event:ScaledEvent { _uid: $uid })-[:HappenedTo]->(deployment:Deployment)
+			WITH {
+				uid: event._uid,
+				type: "ScaledEvent",
+				properties: {
+					time: toString(event.time),
+					from: event.from,
+					to: event.to,
+					reason: event.reason
+				},
+				links: {
+					happenedTo: deployment._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(entry) as json
+		`,
+		event)
+	return event, found, err
+}
+
+func (a *Autoscalers) ListEvents() ([]entities.ScaledEvent, error) {
+	var events []entities.ScaledEvent
+	return events, findAllJson(
+		a.driver,
+		a.ctx,
+		`
+			MATCH (event:ScaledEvent)-[:HappenedTo]->(deployment:Deployment)
+			WITH {
+				uid: event._uid,
+				type: "ScaledEvent",
+				properties: {
+					time: toString(event.time),
+					from: event.from,
+					to: event.to,
+					reason: event.reason
+				},
+				links: {
+					happenedTo: deployment._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&events)
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/knadh/koanf"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/rs/zerolog"
+	"sync"
 )
 
-func ConnectToNeo4j(config *koanf.Koanf, logger zerolog.Logger, ctx context.Context) (neo4j.SessionWithContext, error) {
+// ConnectToNeo4j creates a driver that is safe to share between goroutines, the repositories open a new session from it for every operation
+func ConnectToNeo4j(config *koanf.Koanf, logger zerolog.Logger, ctx context.Context) (neo4j.DriverWithContext, error) {
 	connectionString := config.String("neo4j.connection-string")
 
 	auth := neo4j.NoAuth()
@@ -41,5 +43,56 @@ func ConnectToNeo4j(config *koanf.Koanf, logger zerolog.Logger, ctx context.Cont
 
 	logger.Info().Msg("Connected to Neo4j")
 
-	return driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite}), nil
+	return WithSharedBookmarks(driver), nil
+}
+
+// bookmarkingDriver is a neo4j.DriverWithContext that uses the same BookmarkManager for all sessions,
+// so that each session observes the writes of the sessions that were closed before it was opened
+type bookmarkingDriver struct {
+	neo4j.DriverWithContext
+	bookmarks neo4j.BookmarkManager
+}
+
+// WithSharedBookmarks wraps the driver so that all sessions share a single BookmarkManager
+func WithSharedBookmarks(driver neo4j.DriverWithContext) neo4j.DriverWithContext {
+	return &bookmarkingDriver{
+		DriverWithContext: driver,
+		bookmarks:         &lockedBookmarkManager{bookmarks: neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{})},
+	}
+}
+
+func (d *bookmarkingDriver) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	config.BookmarkManager = d.bookmarks
+	return d.DriverWithContext.NewSession(ctx, config)
+}
+
+// lockedBookmarkManager is a neo4j.BookmarkManager that can be shared by concurrent sessions,
+// since the BookmarkManager of the driver updates the stored bookmarks in place without locking them
+type lockedBookmarkManager struct {
+	lock      sync.Mutex
+	bookmarks neo4j.BookmarkManager
+}
+
+func (m *lockedBookmarkManager) UpdateBookmarks(ctx context.Context, database string, previousBookmarks, newBookmarks neo4j.Bookmarks) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.bookmarks.UpdateBookmarks(ctx, database, previousBookmarks, newBookmarks)
+}
+
+func (m *lockedBookmarkManager) GetAllBookmarks(ctx context.Context) (neo4j.Bookmarks, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.bookmarks.GetAllBookmarks(ctx)
+}
+
+func (m *lockedBookmarkManager) GetBookmarks(ctx context.Context, database string) (neo4j.Bookmarks, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.bookmarks.GetBookmarks(ctx, database)
+}
+
+func (m *lockedBookmarkManager) Forget(ctx context.Context, databases ...string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.bookmarks.Forget(ctx, databases...)
 }
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type Clusters struct {
+	driver neo4j.DriverWithContext
+	ctx    context.Context
+}
+
+func NewClusters(driver neo4j.DriverWithContext, ctx context.Context) *Clusters {
+	return &Clusters{
+		driver: driver,
+		ctx:    ctx,
+	}
+}
+
+func (c *Clusters) Set(cluster entities.Cluster) error {
+	return c.SetMany([]entities.Cluster{cluster})
+}
+
+func (c *Clusters) SetMany(clusters []entities.Cluster) error {
+	batch := make([]any, 0, len(clusters))
+	for _, cluster := range clusters {
+		batch = append(batch, map[string]any{
+			"uid":     cluster.UID,
+			"name":    cluster.Properties.Name,
+			"server":  cluster.Properties.Server,
+			"version": cluster.Properties.Version,
+		})
+	}
+	return multiUpdateMany(
+		c.driver,
+		c.ctx,
+		batch,
+		`
+			UNWIND $batch AS row
+			MERGE (cluster:Cluster { _uid: row.uid })
+			SET cluster = { _uid: row.uid, name: row.name, server: row.server, version: row.version }
+			RETURN id(cluster)
+		`)
+}
+
+func (c *Clusters) List() ([]entities.Cluster, error) {
+	var clusters []entities.Cluster
+	return clusters, findAllJson(
+		c.driver,
+		c.ctx,
+		`
+			MATCH (cluster:Cluster)
+			WITH {
+				uid: cluster._uid,
+				type: "Cluster",
+				properties: {
+					name: cluster.name,
+					server: cluster.server,
+					version: cluster.version
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&clusters)
+}
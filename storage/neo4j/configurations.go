@@ -9,31 +9,42 @@ import (
 	"context"
 	"dolittle.io/fleet-observer/entities"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"time"
 )
 
 type Configurations struct {
-	session neo4j.SessionWithContext
-	ctx     context.Context
+	driver neo4j.DriverWithContext
+	ctx    context.Context
 }
 
-func NewConfigurations(session neo4j.SessionWithContext, ctx context.Context) *Configurations {
+func NewConfigurations(driver neo4j.DriverWithContext, ctx context.Context) *Configurations {
 	return &Configurations{
-		session: session,
-		ctx:     ctx,
+		driver: driver,
+		ctx:    ctx,
 	}
 }
 
 func (c *Configurations) SetArtifact(config entities.ArtifactConfiguration) error {
-	return multiUpdate(
-		c.session,
+	return c.SetManyArtifacts([]entities.ArtifactConfiguration{config})
+}
+
+func (c *Configurations) SetManyArtifacts(configs []entities.ArtifactConfiguration) error {
+	batch := make([]any, 0, len(configs))
+	for _, config := range configs {
+		batch = append(batch, map[string]any{
+			"uid":     config.UID,
+			"hash":    config.Properties.ContentHash,
+			"secrets": config.Properties.Secrets,
+		})
+	}
+	return multiUpdateMany(
+		c.driver,
 		c.ctx,
-		map[string]any{
-			"uid":  config.UID,
-			"hash": config.Properties.ContentHash,
-		},
+		batch,
 		`
-			MERGE (config:ArtifactConfiguration { _uid: $uid })
-			SET config = { _uid: $uid, hash: $hash }
+			UNWIND $batch AS row
+			MERGE (config:ArtifactConfiguration { _uid: row.uid })
+			SET config = { _uid: row.uid, hash: row.hash, secrets: row.secrets }
 			RETURN id(config)
 		`)
 }
@@ -41,7 +52,7 @@ func (c *Configurations) SetArtifact(config entities.ArtifactConfiguration) erro
 func (c *Configurations) ListArtifacts() ([]entities.ArtifactConfiguration, error) {
 	var configs []entities.ArtifactConfiguration
 	return configs, findAllJson(
-		c.session,
+		c.driver,
 		c.ctx,
 		`
 			MATCH (config:ArtifactConfiguration)
@@ -49,7 +60,8 @@ func (c *Configurations) ListArtifacts() ([]entities.ArtifactConfiguration, erro
 				uid: config._uid,
 				type: "ArtifactConfiguration",
 				properties: {
-					hash: config.hash
+					hash: config.hash,
+					secrets: config.secrets
 				}
 			} as entry
 			RETURN apoc.convert.toJson(collect(entry)) as json
@@ -58,16 +70,25 @@ func (c *Configurations) ListArtifacts() ([]entities.ArtifactConfiguration, erro
 }
 
 func (c *Configurations) SetRuntime(config entities.RuntimeConfiguration) error {
-	return multiUpdate(
-		c.session,
-		c.ctx,
-		map[string]any{
+	return c.SetManyRuntimes([]entities.RuntimeConfiguration{config})
+}
+
+func (c *Configurations) SetManyRuntimes(configs []entities.RuntimeConfiguration) error {
+	batch := make([]any, 0, len(configs))
+	for _, config := range configs {
+		batch = append(batch, map[string]any{
 			"uid":  config.UID,
 			"hash": config.Properties.ContentHash,
-		},
+		})
+	}
+	return multiUpdateMany(
+		c.driver,
+		c.ctx,
+		batch,
 		`
-			MERGE (config:RuntimeConfiguration { _uid: $uid })
-			SET config = { _uid: $uid, hash: $hash }
+			UNWIND $batch AS row
+			MERGE (config:RuntimeConfiguration { _uid: row.uid })
+			SET config = { _uid: row.uid, hash: row.hash }
 			RETURN id(config)
 		`)
 }
@@ -75,7 +96,7 @@ func (c *Configurations) SetRuntime(config entities.RuntimeConfiguration) error
 func (c *Configurations) ListRuntimes() ([]entities.RuntimeConfiguration, error) {
 	var configs []entities.RuntimeConfiguration
 	return configs, findAllJson(
-		c.session,
+		c.driver,
 		c.ctx,
 		`
 			MATCH (config:RuntimeConfiguration)
@@ -90,3 +111,131 @@ func (c *Configurations) ListRuntimes() ([]entities.RuntimeConfiguration, error)
 		`,
 		&configs)
 }
+
+func (c *Configurations) SetNode(config entities.NodeConfiguration) error {
+	return c.SetManyNodes([]entities.NodeConfiguration{config})
+}
+
+func (c *Configurations) SetManyNodes(configs []entities.NodeConfiguration) error {
+	batch := make([]any, 0, len(configs))
+	for _, config := range configs {
+		var observedTo any = nil
+		if config.Properties.ObservedTo != nil {
+			observedTo = config.Properties.ObservedTo.Format(time.RFC3339)
+		}
+
+		batch = append(batch, map[string]any{
+			"uid":              config.UID,
+			"hash":             config.Properties.ContentHash,
+			"image":            config.Properties.Image,
+			"type":             config.Properties.Type,
+			"provider":         config.Properties.Provider,
+			"region":           config.Properties.Region,
+			"zone":             config.Properties.Zone,
+			"os":               config.Properties.OS,
+			"osImage":          config.Properties.OSImage,
+			"architecture":     config.Properties.Architecture,
+			"kernel":           config.Properties.Kernel,
+			"kubelet":          config.Properties.Kubelet,
+			"containerRuntime": config.Properties.ContainerRuntime,
+			"cpuCapacity":      config.Properties.Capacity.CPU,
+			"memoryCapacity":   config.Properties.Capacity.Memory,
+			"podsCapacity":     config.Properties.Capacity.Pods,
+			"observedFrom":     config.Properties.ObservedFrom.Format(time.RFC3339),
+			"observedTo":       observedTo,
+			"link_node_uid":    config.Links.ConfigurationOfNodeUID,
+		})
+	}
+	return multiUpdateMany(
+		c.driver,
+		c.ctx,
+		batch,
+		`
+			UNWIND $batch AS row
+			MERGE (config:NodeConfiguration { _uid: row.uid })
+			SET config = {
+				_uid: row.uid,
+				hash: row.hash,
+				image: row.image,
+				type: row.type,
+				provider: row.provider,
+				region: row.region,
+				zone: row.zone,
+				os: row.os,
+				osImage: row.osImage,
+				architecture: row.architecture,
+				kernel: row.kernel,
+				kubelet: row.kubelet,
+				containerRuntime: row.containerRuntime,
+				cpuCapacity: row.cpuCapacity,
+				memoryCapacity: row.memoryCapacity,
+				podsCapacity: row.podsCapacity,
+				observedFrom: datetime(row.observedFrom),
+				observedTo: datetime(row.observedTo)
+			}
+			RETURN id(config)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (config:NodeConfiguration { _uid: row.uid })
+			WITH row, config
+				MERGE (node:Node { _uid: row.link_node_uid})
+				WITH row, config, node
+					MERGE (config)-[:ConfigurationOf]->(node)
+					WITH row, config, node
+						MATCH (config)-[r:ConfigurationOf]->(other)
+						WHERE other._uid <> node._uid
+						DELETE r
+			RETURN id(config)
+		`)
+}
+
+func (c *Configurations) ListNodes() ([]entities.NodeConfiguration, error) {
+	return c.listNodes(nil)
+}
+
+func (c *Configurations) ListNodesOf(node entities.NodeUID) ([]entities.NodeConfiguration, error) {
+	return c.listNodes(string(node))
+}
+
+func (c *Configurations) listNodes(node any) ([]entities.NodeConfiguration, error) {
+	var configs []entities.NodeConfiguration
+	return configs, findAllJsonWith(
+		c.driver,
+		c.ctx,
+		map[string]any{"node": node},
+		`
+			MATCH (config:NodeConfiguration)-[:ConfigurationOf]->(node:Node)
+			WHERE $node IS NULL OR node._uid = $node
+			WITH {
+				uid: config._uid,
+				type: "NodeConfiguration",
+				properties: {
+					hash: config.hash,
+					image: config.image,
+					type: config.type,
+					provider: config.provider,
+					region: config.region,
+					zone: config.zone,
+					os: config.os,
+					osImage: config.osImage,
+					architecture: config.architecture,
+					kernel: config.kernel,
+					kubelet: config.kubelet,
+					containerRuntime: config.containerRuntime,
+					capacity: {
+						cpu: config.cpuCapacity,
+						memory: config.memoryCapacity,
+						pods: config.podsCapacity
+					},
+					observedFrom: toString(config.observedFrom),
+					observedTo: toString(config.observedTo)
+				},
+				links: {
+					configurationOf: node._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&configs)
+}
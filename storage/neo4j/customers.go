@@ -9,40 +9,74 @@ import (
 	"context"
 	"dolittle.io/fleet-observer/entities"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"time"
 )
 
 type Customers struct {
-	session neo4j.SessionWithContext
-	ctx     context.Context
+	driver neo4j.DriverWithContext
+	ctx    context.Context
 }
 
-func NewCustomers(session neo4j.SessionWithContext, ctx context.Context) *Customers {
+func NewCustomers(driver neo4j.DriverWithContext, ctx context.Context) *Customers {
 	return &Customers{
-		session: session,
-		ctx:     ctx,
+		driver: driver,
+		ctx:    ctx,
 	}
 }
 
 func (c *Customers) Set(customer entities.Customer) error {
-	return multiUpdate(
-		c.session,
-		c.ctx,
-		map[string]any{
+	return c.SetMany([]entities.Customer{customer})
+}
+
+func (c *Customers) SetMany(customers []entities.Customer) error {
+	batch := make([]any, 0, len(customers))
+	for _, customer := range customers {
+		batch = append(batch, map[string]any{
 			"uid":  customer.UID,
 			"id":   customer.Properties.ID,
 			"name": customer.Properties.Name,
-		},
+		})
+	}
+	return multiUpdateMany(
+		c.driver,
+		c.ctx,
+		batch,
 		`
-			MERGE (customer:Customer { _uid: $uid })
-			SET customer = { _uid: $uid, id: $id, name: $name }
+			UNWIND $batch AS row
+			MERGE (customer:Customer { _uid: row.uid })
+			SET customer = { _uid: row.uid, id: row.id, name: row.name }
 			RETURN id(customer)
 		`)
 }
 
+func (c *Customers) Get(id entities.CustomerUID) (*entities.Customer, bool, error) {
+	customer := &entities.Customer{}
+	found, err := findSingleJson(
+		c.driver,
+		c.ctx,
+		map[string]any{
+			"uid": id,
+		},
+		`
+			MATCH (customer:Customer { _uid: $uid })
+			WITH {
+				uid: customer._uid,
+				type: "Customer",
+				properties: {
+					id: customer.id,
+					name: customer.name
+				}
+			} as entry
+			RETURN apoc.convert.toJson(entry) as json
+		`,
+		customer)
+	return customer, found, err
+}
+
 func (c *Customers) List() ([]entities.Customer, error) {
 	var customers []entities.Customer
 	return customers, findAllJson(
-		c.session,
+		c.driver,
 		c.ctx,
 		`
 			MATCH (customer:Customer)
@@ -58,3 +92,100 @@ func (c *Customers) List() ([]entities.Customer, error) {
 		`,
 		&customers)
 }
+
+func (c *Customers) SetName(name entities.CustomerName) error {
+	return c.SetManyNames([]entities.CustomerName{name})
+}
+
+func (c *Customers) SetManyNames(names []entities.CustomerName) error {
+	batch := make([]any, 0, len(names))
+	for _, name := range names {
+		var to any = nil
+		if name.Properties.To != nil {
+			to = name.Properties.To.Format(time.RFC3339)
+		}
+		batch = append(batch, map[string]any{
+			"uid":               name.UID,
+			"name":              name.Properties.Name,
+			"from":              name.Properties.From.Format(time.RFC3339),
+			"to":                to,
+			"link_customer_uid": name.Links.NameOfCustomerUID,
+		})
+	}
+	return multiUpdateMany(
+		c.driver,
+		c.ctx,
+		batch,
+		`
+			UNWIND $batch AS row
+			MERGE (name:CustomerName { _uid: row.uid })
+			SET name = { _uid: row.uid, name: row.name, from: datetime(row.from), to: datetime(row.to) }
+			RETURN id(name)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (name:CustomerName { _uid: row.uid })
+			WITH row, name
+				MERGE (customer:Customer { _uid: row.link_customer_uid})
+				WITH row, name, customer
+					MERGE (name)-[:NameOf]->(customer)
+					WITH row, name, customer
+						MATCH (name)-[r:NameOf]->(other)
+						WHERE other._uid <> customer._uid
+						DELETE r
+			RETURN id(name)
+		`)
+}
+
+func (c *Customers) GetCurrentName(id entities.CustomerUID) (*entities.CustomerName, bool, error) {
+	name := &entities.CustomerName{}
+	found, err := findSingleJson(
+		c.driver,
+		c.ctx,
+		map[string]any{
+			"uid": id,
+		},
+		`
+			MATCH (name:CustomerName)-[:NameOf]->(customer:Customer { _uid: $uid })
+			WHERE name.to IS NULL
+			WITH {
+				uid: name._uid,
+				type: "CustomerName",
+				properties: {
+					name: name.name,
+					from: toString(name.from),
+					to: toString(name.to)
+				},
+				links: {
+					nameOf: customer._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(entry) as json
+		`,
+		name)
+	return name, found, err
+}
+
+func (c *Customers) ListNames() ([]entities.CustomerName, error) {
+	var names []entities.CustomerName
+	return names, findAllJson(
+		c.driver,
+		c.ctx,
+		`
+			MATCH (name:CustomerName)-[:NameOf]->(customer:Customer)
+			WITH {
+				uid: name._uid,
+				type: "CustomerName",
+				properties: {
+					name: name.name,
+					from: toString(name.from),
+					to: toString(name.to)
+				},
+				links: {
+					nameOf: customer._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&names)
+}
@@ -13,66 +13,99 @@ import (
 )
 
 type Deployments struct {
-	session neo4j.SessionWithContext
-	ctx     context.Context
+	driver neo4j.DriverWithContext
+	ctx    context.Context
 }
 
-func NewDeployments(session neo4j.SessionWithContext, ctx context.Context) *Deployments {
+func NewDeployments(driver neo4j.DriverWithContext, ctx context.Context) *Deployments {
 	return &Deployments{
-		session: session,
-		ctx:     ctx,
+		driver: driver,
+		ctx:    ctx,
 	}
 }
 
 func (d *Deployments) Set(deployment entities.Deployment) error {
-	return multiUpdate(
-		d.session,
-		d.ctx,
-		map[string]any{
+	return d.SetMany([]entities.Deployment{deployment})
+}
+
+func (d *Deployments) SetMany(deployments []entities.Deployment) error {
+	batch := make([]any, 0, len(deployments))
+	for _, deployment := range deployments {
+		batch = append(batch, map[string]any{
 			"uid":                       deployment.UID,
 			"id":                        deployment.Properties.ID,
 			"name":                      deployment.Properties.Name,
 			"created":                   deployment.Properties.Created.Format(time.RFC3339),
+			"runtimeCpuRequest":         deployment.Properties.Resources.Runtime.CPURequest,
+			"runtimeCpuLimit":           deployment.Properties.Resources.Runtime.CPULimit,
+			"runtimeMemoryRequest":      deployment.Properties.Resources.Runtime.MemoryRequest,
+			"runtimeMemoryLimit":        deployment.Properties.Resources.Runtime.MemoryLimit,
+			"headCpuRequest":            deployment.Properties.Resources.Head.CPURequest,
+			"headCpuLimit":              deployment.Properties.Resources.Head.CPULimit,
+			"headMemoryRequest":         deployment.Properties.Resources.Head.MemoryRequest,
+			"headMemoryLimit":           deployment.Properties.Resources.Head.MemoryLimit,
 			"link_environment_uid":      deployment.Links.DeployedInEnvironmentUID,
 			"link_artifact_version_uid": deployment.Links.UsesArtifactVersionUID,
 			"link_runtime_version_uid":  deployment.Links.UsesRuntimeVersionUID,
-		},
+		})
+	}
+	return multiUpdateMany(
+		d.driver,
+		d.ctx,
+		batch,
 		`
-			MERGE (deployment:Deployment { _uid: $uid })
-			SET deployment = { _uid: $uid, id: $id, name: $name, created: datetime($created) }
+			UNWIND $batch AS row
+			MERGE (deployment:Deployment { _uid: row.uid })
+			SET deployment = {
+				_uid: row.uid,
+				id: row.id,
+				name: row.name,
+				created: datetime(row.created),
+				runtimeCpuRequest: row.runtimeCpuRequest,
+				runtimeCpuLimit: row.runtimeCpuLimit,
+				runtimeMemoryRequest: row.runtimeMemoryRequest,
+				runtimeMemoryLimit: row.runtimeMemoryLimit,
+				headCpuRequest: row.headCpuRequest,
+				headCpuLimit: row.headCpuLimit,
+				headMemoryRequest: row.headMemoryRequest,
+				headMemoryLimit: row.headMemoryLimit
+			}
 			RETURN id(deployment)
 		`,
 		`
-			MATCH (deployment:Deployment { _uid: $uid })
-			WITH deployment
-				MERGE (environment:Environment { _uid: $link_environment_uid})
-				WITH deployment, environment
+			UNWIND $batch AS row
+			MATCH (deployment:Deployment { _uid: row.uid })
+			WITH row, deployment
+				MERGE (environment:Environment { _uid: row.link_environment_uid})
+				WITH row, deployment, environment
 					MERGE (deployment)-[:DeployedIn]->(environment)
-					WITH deployment, environment
+					WITH row, deployment, environment
 						MATCH (deployment)-[r:DeployedIn]->(other)
 						WHERE other._uid <> environment._uid
 						DELETE r
 			RETURN id(deployment)
 		`,
 		`
-			MATCH (deployment:Deployment { _uid: $uid })
-			WITH deployment
-				MERGE (version:ArtifactVersion { _uid: $link_artifact_version_uid})
-				WITH deployment, version
+			UNWIND $batch AS row
+			MATCH (deployment:Deployment { _uid: row.uid })
+			WITH row, deployment
+				MERGE (version:ArtifactVersion { _uid: row.link_artifact_version_uid})
+				WITH row, deployment, version
 					MERGE (deployment)-[:UsesArtifact]->(version)
-					WITH deployment, version
+					WITH row, deployment, version
 						MATCH (deployment)-[r:UsesArtifact]->(other)
 						WHERE other._uid <> version._uid
 						DELETE r
 			RETURN id(deployment)
 		`,
 		`
-			MATCH (deployment:Deployment { _uid: $uid })
-			WITH deployment
-				MERGE (version:RuntimeVersion { _uid: $link_runtime_version_uid})
-				WITH deployment, version
+			UNWIND $batch AS row
+			MATCH (deployment:Deployment { _uid: row.uid })
+			WITH row, deployment
+				MERGE (version:RuntimeVersion { _uid: row.link_runtime_version_uid})
+				WITH row, deployment, version
 					MERGE (deployment)-[:UsesRuntime]->(version)
-					WITH deployment, version
+					WITH row, deployment, version
 						MATCH (deployment)-[r:UsesRuntime]->(other)
 						WHERE other._uid <> version._uid
 						DELETE r
@@ -83,7 +116,7 @@ func (d *Deployments) Set(deployment entities.Deployment) error {
 func (d *Deployments) Get(id entities.DeploymentUID) (*entities.Deployment, bool, error) {
 	deployment := &entities.Deployment{}
 	found, err := findSingleJson(
-		d.session,
+		d.driver,
 		d.ctx,
 		map[string]any{
 			"uid": id,
@@ -99,7 +132,22 @@ func (d *Deployments) Get(id entities.DeploymentUID) (*entities.Deployment, bool
 				type: "Deployment",
 				properties: {
 					id: deployment.id,
-					created: toString(deployment.created)
+					name: deployment.name,
+					created: toString(deployment.created),
+					resources: {
+						runtime: {
+							cpuRequest: deployment.runtimeCpuRequest,
+							cpuLimit: deployment.runtimeCpuLimit,
+							memoryRequest: deployment.runtimeMemoryRequest,
+							memoryLimit: deployment.runtimeMemoryLimit
+						},
+						head: {
+							cpuRequest: deployment.headCpuRequest,
+							cpuLimit: deployment.headCpuLimit,
+							memoryRequest: deployment.headMemoryRequest,
+							memoryLimit: deployment.headMemoryLimit
+						}
+					}
 				},
 				links: {
 					deployedIn: environment._uid,
@@ -116,7 +164,7 @@ func (d *Deployments) Get(id entities.DeploymentUID) (*entities.Deployment, bool
 func (d *Deployments) List() ([]entities.Deployment, error) {
 	var deployments []entities.Deployment
 	return deployments, findAllJson(
-		d.session,
+		d.driver,
 		d.ctx,
 		`
 			MATCH (deployment:Deployment)-[:DeployedIn]->(environment:Environment)
@@ -129,7 +177,22 @@ func (d *Deployments) List() ([]entities.Deployment, error) {
 				type: "Deployment",
 				properties: {
 					id: deployment.id,
-					created: toString(deployment.created)
+					name: deployment.name,
+					created: toString(deployment.created),
+					resources: {
+						runtime: {
+							cpuRequest: deployment.runtimeCpuRequest,
+							cpuLimit: deployment.runtimeCpuLimit,
+							memoryRequest: deployment.runtimeMemoryRequest,
+							memoryLimit: deployment.runtimeMemoryLimit
+						},
+						head: {
+							cpuRequest: deployment.headCpuRequest,
+							cpuLimit: deployment.headCpuLimit,
+							memoryRequest: deployment.headMemoryRequest,
+							memoryLimit: deployment.headMemoryLimit
+						}
+					}
 				},
 				links: {
 					deployedIn: environment._uid,
@@ -143,81 +206,150 @@ func (d *Deployments) List() ([]entities.Deployment, error) {
 }
 
 func (d *Deployments) SetInstance(instance entities.DeploymentInstance) error {
-	var stopped any = nil
-	if instance.Properties.Stopped != nil {
-		stopped = instance.Properties.Stopped.Format(time.RFC3339)
-	}
-	return multiUpdate(
-		d.session,
-		d.ctx,
-		map[string]any{
+	return d.SetManyInstances([]entities.DeploymentInstance{instance})
+}
+
+func (d *Deployments) SetManyInstances(instances []entities.DeploymentInstance) error {
+	batch := make([]any, 0, len(instances))
+	for _, instance := range instances {
+		var stopped any = nil
+		if instance.Properties.Stopped != nil {
+			stopped = instance.Properties.Stopped.Format(time.RFC3339)
+		}
+		var qosClass any = nil
+		if instance.Properties.QOSClass != "" {
+			qosClass = instance.Properties.QOSClass
+		}
+		var artifactDigest any = nil
+		if instance.Properties.ArtifactDigest != "" {
+			artifactDigest = instance.Properties.ArtifactDigest
+		}
+		var runtimeDigest any = nil
+		if instance.Properties.RuntimeDigest != "" {
+			runtimeDigest = instance.Properties.RuntimeDigest
+		}
+		var status, duration any = nil, nil
+		if instance.Properties.Status != "" {
+			status = instance.Properties.Status
+		}
+		if instance.Properties.Duration != nil {
+			duration = *instance.Properties.Duration
+		}
+		var nodeConfig any = nil
+		if instance.Links.RanOnNodeConfigurationUID != "" {
+			nodeConfig = instance.Links.RanOnNodeConfigurationUID
+		}
+		batch = append(batch, map[string]any{
 			"uid":                      instance.UID,
 			"id":                       instance.Properties.ID,
 			"started":                  instance.Properties.Started.Format(time.RFC3339),
 			"stopped":                  stopped,
+			"qosClass":                 qosClass,
+			"artifactDigest":           artifactDigest,
+			"runtimeDigest":            runtimeDigest,
+			"status":                   status,
+			"duration":                 duration,
 			"link_deployment_uid":      instance.Links.InstanceOfDeploymentUID,
 			"link_artifact_config_uid": instance.Links.UsesArtifactConfigurationUID,
 			"link_runtime_config_uid":  instance.Links.UsesRuntimeConfigurationUID,
 			"link_node_uid":            instance.Links.ScheduledOnNodeUID,
-		},
+			"link_node_config_uid":     nodeConfig,
+		})
+	}
+	return multiUpdateMany(
+		d.driver,
+		d.ctx,
+		batch,
 		`
-			MERGE (instance:DeploymentInstance { _uid: $uid })
-			SET instance = { _uid: $uid, id: $id, started: datetime($started), stopped: datetime($stopped) }
+			UNWIND $batch AS row
+			MERGE (instance:DeploymentInstance { _uid: row.uid })
+			SET instance = {
+				_uid: row.uid,
+				id: row.id,
+				started: datetime(row.started),
+				stopped: datetime(row.stopped),
+				qosClass: row.qosClass,
+				artifactDigest: row.artifactDigest,
+				runtimeDigest: row.runtimeDigest,
+				status: row.status,
+				duration: row.duration
+			}
 			RETURN id(instance)
 		`,
 		`
-			MATCH (instance:DeploymentInstance { _uid: $uid })
-			WITH instance
-				MERGE (deployment:Deployment { _uid: $link_deployment_uid })
-				WITH instance, deployment
+			UNWIND $batch AS row
+			MATCH (instance:DeploymentInstance { _uid: row.uid })
+			WITH row, instance
+				MERGE (deployment:Deployment { _uid: row.link_deployment_uid })
+				WITH row, instance, deployment
 					MERGE (instance)-[:InstanceOf]->(deployment)
-					WITH instance, deployment
+					WITH row, instance, deployment
 						MATCH (instance)-[r:InstanceOf]->(other)
 						WHERE other._uid <> deployment._uid
 						DELETE r
 			RETURN id(instance)
 		`,
 		`
-			MATCH (instance:DeploymentInstance { _uid: $uid })
-			WITH instance
-				MERGE (config:ArtifactConfiguration { _uid: $link_artifact_config_uid})
-				WITH instance, config
+			UNWIND $batch AS row
+			MATCH (instance:DeploymentInstance { _uid: row.uid })
+			WITH row, instance
+				MERGE (config:ArtifactConfiguration { _uid: row.link_artifact_config_uid})
+				WITH row, instance, config
 					MERGE (instance)-[:UsesArtifactConfiguration]->(config)
-					WITH instance, config
+					WITH row, instance, config
 						MATCH (instance)-[r:UsesArtifactConfiguration]->(other)
 						WHERE other._uid <> config._uid
 						DELETE r
 			RETURN id(instance)
 		`,
 		`
-			MATCH (instance:DeploymentInstance { _uid: $uid })
-			WITH instance
-				MERGE (config:RuntimeConfiguration { _uid: $link_runtime_config_uid})
-				WITH instance, config
+			UNWIND $batch AS row
+			MATCH (instance:DeploymentInstance { _uid: row.uid })
+			WITH row, instance
+				MERGE (config:RuntimeConfiguration { _uid: row.link_runtime_config_uid})
+				WITH row, instance, config
 					MERGE (instance)-[:UsesRuntimeConfiguration]->(config)
-					WITH instance, config
+					WITH row, instance, config
 						MATCH (instance)-[r:UsesRuntimeConfiguration]->(other)
 						WHERE other._uid <> config._uid
 						DELETE r
 			RETURN id(instance)
 		`,
 		`
-			MATCH (instance:DeploymentInstance { _uid: $uid })
-			WITH instance
-				MERGE (node:Node { _uid: $link_node_uid})
-				WITH instance, node
+			UNWIND $batch AS row
+			MATCH (instance:DeploymentInstance { _uid: row.uid })
+			WITH row, instance
+				MERGE (node:Node { _uid: row.link_node_uid})
+				WITH row, instance, node
 					MERGE (instance)-[:ScheduledOn]->(node)
-					WITH instance, node
+					WITH row, instance, node
 						MATCH (instance)-[r:ScheduledOn]->(other)
 						WHERE other._uid <> node._uid
 						DELETE r
 			RETURN id(instance)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (instance:DeploymentInstance { _uid: row.uid })-[r:RanOn]->(other)
+			WHERE row.link_node_config_uid IS NULL OR other._uid <> row.link_node_config_uid
+			DELETE r
+			RETURN id(instance)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (instance:DeploymentInstance { _uid: row.uid })
+			WHERE row.link_node_config_uid IS NOT NULL
+			WITH row, instance
+				MERGE (config:NodeConfiguration { _uid: row.link_node_config_uid })
+				WITH row, instance, config
+					MERGE (instance)-[:RanOn]->(config)
+			RETURN id(instance)
 		`)
 }
 func (d *Deployments) GetInstance(id entities.DeploymentInstanceUID) (*entities.DeploymentInstance, bool, error) {
 	instance := &entities.DeploymentInstance{}
 	found, err := findSingleJson(
-		d.session,
+		d.driver,
 		d.ctx,
 		map[string]any{
 			"uid": id,
@@ -230,19 +362,26 @@ func (d *Deployments) GetInstance(id entities.DeploymentInstanceUID) (*entities.
 				MATCH (instance)-[:UsesRuntimeConfiguration]->(runtime:RuntimeConfiguration)
 			WITH instance, deployment, artifact, runtime
 				MATCH (instance)-[:ScheduledOn]->(node:Node)
+			OPTIONAL MATCH (instance)-[:RanOn]->(nodeConfig:NodeConfiguration)
 			WITH {
 				uid: instance._uid,
 				type: "DeploymentInstance",
 				properties: {
 					id: instance.id,
 					started: toString(instance.started),
-					stopped: toString(instance.stopped)
+					stopped: toString(instance.stopped),
+					qosClass: instance.qosClass,
+					artifactDigest: instance.artifactDigest,
+					runtimeDigest: instance.runtimeDigest,
+					status: instance.status,
+					duration: instance.duration
 				},
 				links: {
 					instanceOf: deployment._uid,
 					usesArtifactConfiguration: artifact._uid,
 					usesRuntimeConfiguration: runtime._uid,
-					scheduledOn: node._uid
+					scheduledOn: node._uid,
+					ranOn: nodeConfig._uid
 				}
 			} as entry
 			RETURN apoc.convert.toJson(entry) as json
@@ -254,7 +393,7 @@ func (d *Deployments) GetInstance(id entities.DeploymentInstanceUID) (*entities.
 func (d *Deployments) ListInstances() ([]entities.DeploymentInstance, error) {
 	var instances []entities.DeploymentInstance
 	return instances, findAllJson(
-		d.session,
+		d.driver,
 		d.ctx,
 		`
 			MATCH (instance:DeploymentInstance)-[:InstanceOf]->(deployment:Deployment)
@@ -264,19 +403,26 @@ func (d *Deployments) ListInstances() ([]entities.DeploymentInstance, error) {
 				MATCH (instance)-[:UsesRuntimeConfiguration]->(runtime:RuntimeConfiguration)
 			WITH instance, deployment, artifact, runtime
 				MATCH (instance)-[:ScheduledOn]->(node:Node)
+			OPTIONAL MATCH (instance)-[:RanOn]->(nodeConfig:NodeConfiguration)
 			WITH {
 				uid: instance._uid,
 				type: "DeploymentInstance",
 				properties: {
 					id: instance.id,
 					started: toString(instance.started),
-					stopped: toString(instance.stopped)
+					stopped: toString(instance.stopped),
+					qosClass: instance.qosClass,
+					artifactDigest: instance.artifactDigest,
+					runtimeDigest: instance.runtimeDigest,
+					status: instance.status,
+					duration: instance.duration
 				},
 				links: {
 					instanceOf: deployment._uid,
 					usesArtifactConfiguration: artifact._uid,
 					usesRuntimeConfiguration: runtime._uid,
-					scheduledOn: node._uid
+					scheduledOn: node._uid,
+					ranOn: nodeConfig._uid
 				}
 			} as entry
 			RETURN apoc.convert.toJson(collect(entry)) as json
@@ -287,7 +433,7 @@ func (d *Deployments) ListInstances() ([]entities.DeploymentInstance, error) {
 func (d *Deployments) ListRunningInstances() ([]entities.DeploymentInstance, error) {
 	var instances []entities.DeploymentInstance
 	return instances, findAllJson(
-		d.session,
+		d.driver,
 		d.ctx,
 		`
 			MATCH (instance:DeploymentInstance)-[:InstanceOf]->(deployment:Deployment)
@@ -298,19 +444,26 @@ func (d *Deployments) ListRunningInstances() ([]entities.DeploymentInstance, err
 				MATCH (instance)-[:UsesRuntimeConfiguration]->(runtime:RuntimeConfiguration)
 			WITH instance, deployment, artifact, runtime
 				MATCH (instance)-[:ScheduledOn]->(node:Node)
+			OPTIONAL MATCH (instance)-[:RanOn]->(nodeConfig:NodeConfiguration)
 			WITH {
 				uid: instance._uid,
 				type: "DeploymentInstance",
 				properties: {
 					id: instance.id,
 					started: toString(instance.started),
-					stopped: toString(instance.stopped)
+					stopped: toString(instance.stopped),
+					qosClass: instance.qosClass,
+					artifactDigest: instance.artifactDigest,
+					runtimeDigest: instance.runtimeDigest,
+					status: instance.status,
+					duration: instance.duration
 				},
 				links: {
 					instanceOf: deployment._uid,
 					usesArtifactConfiguration: artifact._uid,
 					usesRuntimeConfiguration: runtime._uid,
-					scheduledOn: node._uid
+					scheduledOn: node._uid,
+					ranOn: nodeConfig._uid
 				}
 			} as entry
 			RETURN apoc.convert.toJson(collect(entry)) as json
@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"time"
+)
+
+type Endpoints struct {
+	driver neo4j.DriverWithContext
+	ctx    context.Context
+}
+
+func NewEndpoints(driver neo4j.DriverWithContext, ctx context.Context) *Endpoints {
+	return &Endpoints{
+		driver: driver,
+		ctx:    ctx,
+	}
+}
+
+func (e *Endpoints) Set(endpoint entities.Endpoint) error {
+	return e.SetMany([]entities.Endpoint{endpoint})
+}
+
+func (e *Endpoints) SetMany(endpoints []entities.Endpoint) error {
+	batch := make([]any, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		var artifact any = nil
+		if endpoint.Links.RoutesToArtifactUID != "" {
+			artifact = endpoint.Links.RoutesToArtifactUID
+		}
+		var deleted any = nil
+		if endpoint.Properties.Deleted != nil {
+			deleted = endpoint.Properties.Deleted.Format(time.RFC3339)
+		}
+		batch = append(batch, map[string]any{
+			"uid":                  endpoint.UID,
+			"kind":                 endpoint.Properties.Kind,
+			"name":                 endpoint.Properties.Name,
+			"host":                 endpoint.Properties.Host,
+			"path":                 endpoint.Properties.Path,
+			"port":                 endpoint.Properties.Port,
+			"tls":                  endpoint.Properties.TLS,
+			"public":               endpoint.Properties.Public,
+			"deleted":              deleted,
+			"link_environment_uid": endpoint.Links.ExposedInEnvironmentUID,
+			"link_artifact_uid":    artifact,
+		})
+	}
+	return multiUpdateMany(
+		e.driver,
+		e.ctx,
+		batch,
+		`
+			UNWIND $batch AS row
+			MERGE (endpoint:Endpoint { _uid: row.uid })
+			SET endpoint = {
+				_uid: row.uid,
+				kind: row.kind,
+				name: row.name,
+				host: row.host,
+				path: row.path,
+				port: row.port,
+				tls: row.tls,
+				public: row.public,
+				deleted: datetime(row.deleted)
+			}
+			RETURN id(endpoint)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (endpoint:Endpoint { _uid: row.uid })
+			WITH row, endpoint
+				MERGE (environment:Environment { _uid: row.link_environment_uid })
+				WITH row, endpoint, environment
+					MERGE (endpoint)-[:ExposedIn]->(environment)
+					WITH row, endpoint, environment
+						MATCH (endpoint)-[r:ExposedIn]->(other)
+						WHERE other._uid <> environment._uid
+						DELETE r
+			RETURN id(endpoint)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (endpoint:Endpoint { _uid: row.uid })-[r:RoutesTo]->(other)
+			WHERE row.link_artifact_uid IS NULL OR other._uid <> row.link_artifact_uid
+			DELETE r
+			RETURN id(endpoint)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (endpoint:Endpoint { _uid: row.uid })
+			WHERE row.link_artifact_uid IS NOT NULL
+			WITH row, endpoint
+				MERGE (artifact:Artifact { _uid: row.link_artifact_uid })
+				WITH row, endpoint, artifact
+					MERGE (endpoint)-[:RoutesTo]->(artifact)
+			RETURN id(endpoint)
+		`)
+}
+
+func (e *Endpoints) List() ([]entities.Endpoint, error) {
+	return e.listExposedIn(nil)
+}
+
+func (e *Endpoints) ListExposedIn(environment entities.EnvironmentUID) ([]entities.Endpoint, error) {
+	return e.listExposedIn(string(environment))
+}
+
+func (e *Endpoints) listExposedIn(environment any) ([]entities.Endpoint, error) {
+	var endpoints []entities.Endpoint
+	return endpoints, findAllJsonWith(
+		e.driver,
+		e.ctx,
+		map[string]any{"environment": environment},
+		`
+			MATCH (endpoint:Endpoint)-[:ExposedIn]->(environment:Environment)
+			WHERE $environment IS NULL OR environment._uid = $environment
+			OPTIONAL MATCH (endpoint)-[:RoutesTo]->(artifact:Artifact)
+			WITH {
+				uid: endpoint._uid,
+				type: "Endpoint",
+				properties: {
+					kind: endpoint.kind,
+					name: endpoint.name,
+					host: endpoint.host,
+					path: endpoint.path,
+					port: endpoint.port,
+					tls: endpoint.tls,
+					public: endpoint.public,
+					deleted: toString(endpoint.deleted)
+				},
+				links: {
+					exposedIn: environment._uid,
+					routesTo: artifact._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&endpoints)
+}
@@ -12,55 +12,78 @@ import (
 )
 
 type Environments struct {
-	session neo4j.SessionWithContext
-	ctx     context.Context
+	driver neo4j.DriverWithContext
+	ctx    context.Context
 }
 
-func NewEnvironments(session neo4j.SessionWithContext, ctx context.Context) *Environments {
+func NewEnvironments(driver neo4j.DriverWithContext, ctx context.Context) *Environments {
 	return &Environments{
-		session: session,
-		ctx:     ctx,
+		driver: driver,
+		ctx:    ctx,
 	}
 }
 
 func (e *Environments) Set(environment entities.Environment) error {
-	return multiUpdate(
-		e.session,
-		e.ctx,
-		map[string]any{
+	return e.SetMany([]entities.Environment{environment})
+}
+
+func (e *Environments) SetMany(environments []entities.Environment) error {
+	batch := make([]any, 0, len(environments))
+	for _, environment := range environments {
+		batch = append(batch, map[string]any{
 			"uid":                  environment.UID,
 			"name":                 environment.Properties.Name,
 			"link_application_uid": environment.Links.EnvironmentOfApplicationUID,
-		},
+			"link_cluster_uids":    environment.Links.RunsInClusterUIDs,
+		})
+	}
+	return multiUpdateMany(
+		e.driver,
+		e.ctx,
+		batch,
 		`
-			MERGE (environment:Environment { _uid: $uid })
-			SET environment = { _uid: $uid, name: $name }
+			UNWIND $batch AS row
+			MERGE (environment:Environment { _uid: row.uid })
+			SET environment = { _uid: row.uid, name: row.name }
 			RETURN id(environment)
 		`,
 		`
-			MATCH (environment:Environment { _uid: $uid })
-			WITH environment
-				MERGE (application:Application { _uid: $link_application_uid })
-				WITH environment, application
+			UNWIND $batch AS row
+			MATCH (environment:Environment { _uid: row.uid })
+			WITH row, environment
+				MERGE (application:Application { _uid: row.link_application_uid })
+				WITH row, environment, application
 					MERGE (environment)-[:EnvironmentOf]->(application)
-					WITH environment, application
+					WITH row, environment, application
 						MATCH (environment)-[r:EnvironmentOf]->(other)
 						WHERE other._uid <> application._uid
 						DELETE r
 			RETURN id(environment)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (environment:Environment { _uid: row.uid })
+			WITH row, environment
+				UNWIND row.link_cluster_uids AS link_cluster_uid
+				MERGE (cluster:Cluster { _uid: link_cluster_uid })
+				WITH row, environment, cluster
+					MERGE (environment)-[:RunsIn]->(cluster)
+			RETURN id(environment)
 		`)
 }
 
 func (e *Environments) Get(id entities.EnvironmentUID) (*entities.Environment, bool, error) {
 	environment := &entities.Environment{}
 	found, err := findSingleJson(
-		e.session,
+		e.driver,
 		e.ctx,
 		map[string]any{
 			"uid": id,
 		},
 		`
 			MATCH (environment:Environment { _uid: $uid })-[:EnvironmentOf]->(application:Application)
+			OPTIONAL MATCH (environment)-[:RunsIn]->(cluster:Cluster)
+			WITH environment, application, collect(cluster._uid) as clusters
 			WITH {
 				uid: environment._uid,
 				type: "Environment",
@@ -68,7 +91,8 @@ func (e *Environments) Get(id entities.EnvironmentUID) (*entities.Environment, b
 					name: environment.name
 				},
 				links: {
-					environmentOf: application._uid
+					environmentOf: application._uid,
+					runsIn: clusters
 				}
 			} as entry
 			RETURN apoc.convert.toJson(entry) as json
@@ -80,10 +104,12 @@ func (e *Environments) Get(id entities.EnvironmentUID) (*entities.Environment, b
 func (e *Environments) List() ([]entities.Environment, error) {
 	var environments []entities.Environment
 	return environments, findAllJson(
-		e.session,
+		e.driver,
 		e.ctx,
 		`
 			MATCH (environment:Environment)-[:EnvironmentOf]->(application:Application)
+			OPTIONAL MATCH (environment)-[:RunsIn]->(cluster:Cluster)
+			WITH environment, application, collect(cluster._uid) as clusters
 			WITH {
 				uid: environment._uid,
 				type: "Environment",
@@ -91,7 +117,8 @@ func (e *Environments) List() ([]entities.Environment, error) {
 					name: environment.name
 				},
 				links: {
-					environmentOf: application._uid
+					environmentOf: application._uid,
+					runsIn: clusters
 				}
 			} as entry
 			RETURN apoc.convert.toJson(collect(entry)) as json
@@ -13,52 +13,100 @@ import (
 )
 
 type Events struct {
-	session neo4j.SessionWithContext
-	ctx     context.Context
+	driver neo4j.DriverWithContext
+	ctx    context.Context
 }
 
-func NewEvents(session neo4j.SessionWithContext, ctx context.Context) *Events {
+func NewEvents(driver neo4j.DriverWithContext, ctx context.Context) *Events {
 	return &Events{
-		session: session,
-		ctx:     ctx,
+		driver: driver,
+		ctx:    ctx,
 	}
 }
 
 func (e *Events) Set(event entities.Event) error {
-	return multiUpdate(
-		e.session,
-		e.ctx,
-		map[string]any{
+	return e.SetMany([]entities.Event{event})
+}
+
+func (e *Events) SetMany(events []entities.Event) error {
+	batches := map[string][]any{}
+	for _, event := range events {
+		var container, reason, exitCode, signal, digest any = nil, nil, nil, nil, nil
+		if event.Properties.Container != "" {
+			container = event.Properties.Container
+		}
+		if event.Properties.Reason != "" {
+			reason = event.Properties.Reason
+		}
+		if event.Properties.ExitCode != nil {
+			exitCode = *event.Properties.ExitCode
+		}
+		if event.Properties.Signal != 0 {
+			signal = event.Properties.Signal
+		}
+		if event.Properties.Digest != "" {
+			digest = event.Properties.Digest
+		}
+		batches[event.Type] = append(batches[event.Type], map[string]any{
 			"uid":               event.UID,
 			"count":             event.Properties.Count,
 			"firstTime":         event.Properties.FirstTime.Format(time.RFC3339),
 			"lastTime":          event.Properties.LastTime.Format(time.RFC3339),
 			"platform":          event.Properties.Platform,
+			"container":         container,
+			"reason":            reason,
+			"exitCode":          exitCode,
+			"signal":            signal,
+			"digest":            digest,
 			"link_instance_uid": event.Links.HappenedToDeploymentInstanceUID,
-		},
-		`
-			MERGE (event:`+event.Type+`:Event { _uid: $uid })
-			SET event = { _uid: $uid, count: $count, firstTime: $firstTime, lastTime: $lastTime, platform: $platform }
-			RETURN id(event)
-		`,
-		`
-			MATCH (event:Event { _uid: $uid })
-			WITH event
-				MERGE (instance:DeploymentInstance { _uid: $link_instance_uid })
-				WITH event, instance
-					MERGE (event)-[:HappenedTo]->(instance)
-					WITH event, instance
-						MATCH (event)-[r:HappenedTo]->(other)
-						WHERE other._uid <> instance._uid
-						DELETE r
-			RETURN id(event)
-		`)
+		})
+	}
+	for eventType, batch := range batches {
+		err := multiUpdateMany(
+			e.driver,
+			e.ctx,
+			batch,
+			`
+				UNWIND $batch AS row
+				MERGE (event:`+eventType+`:Event { _uid: row.uid })
+				SET event = {
+					_uid: row.uid,
+					count: row.count,
+					firstTime: row.firstTime,
+					lastTime: row.lastTime,
+					platform: row.platform,
+					container: row.container,
+					reason: row.reason,
+					exitCode: row.exitCode,
+					signal: row.signal,
+					digest: row.digest
+				}
+				RETURN id(event)
+			`,
+			`
+				UNWIND $batch AS row
+				MATCH (event:Event { _uid: row.uid })
+				WITH row, event
+					MERGE (instance:DeploymentInstance { _uid: row.link_instance_uid })
+					WITH row, event, instance
+						MERGE (event)-[:HappenedTo]->(instance)
+						WITH row, event, instance
+							MATCH (event)-[r:HappenedTo]->(other)
+							WHERE other._uid <> instance._uid
+							DELETE r
+				RETURN id(event)
+			`)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (e *Events) Get(id entities.EventUID) (*entities.Event, bool, error) {
 	event := &entities.Event{}
 	found, err := findSingleJson(
-		e.session,
+		e.driver,
 		e.ctx,
 		map[string]any{
 			"uid": id,
@@ -72,7 +120,12 @@ func (e *Events) Get(id entities.EventUID) (*entities.Event, bool, error) {
 					count: event.count,
 					firstTime: toString(event.firstTime),
 					lastTime: toString(event.lastTime),
-					platform: event.platform
+					platform: event.platform,
+					container: event.container,
+					reason: event.reason,
+					exitCode: event.exitCode,
+					signal: event.signal,
+					digest: event.digest
 				},
 				links: {
 					happenedTo: instance._uid
@@ -87,7 +140,7 @@ func (e *Events) Get(id entities.EventUID) (*entities.Event, bool, error) {
 func (e *Events) List() ([]entities.Event, error) {
 	var events []entities.Event
 	return events, findAllJson(
-		e.session,
+		e.driver,
 		e.ctx,
 		`
 			MATCH (event:Event)-[:HappenedTo]->(instance:DeploymentInstance)
@@ -98,7 +151,12 @@ func (e *Events) List() ([]entities.Event, error) {
 					count: event.count,
 					firstTime: toString(event.firstTime),
 					lastTime: toString(event.lastTime),
-					platform: event.platform
+					platform: event.platform,
+					container: event.container,
+					reason: event.reason,
+					exitCode: event.exitCode,
+					signal: event.signal,
+					digest: event.digest
 				},
 				links: {
 					happenedTo: instance._uid
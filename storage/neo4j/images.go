@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"time"
+)
+
+type Images struct {
+	driver neo4j.DriverWithContext
+	ctx    context.Context
+}
+
+func NewImages(driver neo4j.DriverWithContext, ctx context.Context) *Images {
+	return &Images{
+		driver: driver,
+		ctx:    ctx,
+	}
+}
+
+func (i *Images) SetRelease(release entities.ImageRelease) error {
+	return i.SetManyReleases([]entities.ImageRelease{release})
+}
+
+func (i *Images) SetManyReleases(releases []entities.ImageRelease) error {
+	batch := make([]any, 0, len(releases))
+	for _, release := range releases {
+		var released any = nil
+		if release.Properties.Released != nil {
+			released = release.Properties.Released.Format(time.RFC3339)
+		}
+		batch = append(batch, map[string]any{
+			"uid":      release.UID,
+			"image":    release.Properties.Image,
+			"released": released,
+			"resolved": release.Properties.Resolved.Format(time.RFC3339),
+		})
+	}
+	return multiUpdateMany(
+		i.driver,
+		i.ctx,
+		batch,
+		`
+			UNWIND $batch AS row
+			MERGE (release:ImageRelease { _uid: row.uid })
+			SET release = { _uid: row.uid, image: row.image, released: datetime(row.released), resolved: datetime(row.resolved) }
+			RETURN id(release)
+		`)
+}
+
+func (i *Images) GetRelease(id entities.ImageReleaseUID) (*entities.ImageRelease, bool, error) {
+	release := &entities.ImageRelease{}
+	found, err := findSingleJson(
+		i.driver,
+		i.ctx,
+		map[string]any{
+			"uid": id,
+		},
+		`
+			MATCH (release:ImageRelease { _uid: $uid })
+			WITH {
+				uid: release._uid,
+				type: "ImageRelease",
+				properties: {
+					image: release.image,
+					released: toString(release.released),
+					resolved: toString(release.resolved)
+				}
+			} as entry
+			RETURN apoc.convert.toJson(entry) as json
+		`,
+		release)
+	return release, found, err
+}
@@ -9,54 +9,378 @@ import (
 	"context"
 	"dolittle.io/fleet-observer/entities"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"time"
 )
 
 type Nodes struct {
-	session neo4j.SessionWithContext
-	ctx     context.Context
+	driver neo4j.DriverWithContext
+	ctx    context.Context
 }
 
-func NewNodes(session neo4j.SessionWithContext, ctx context.Context) *Nodes {
+func NewNodes(driver neo4j.DriverWithContext, ctx context.Context) *Nodes {
 	return &Nodes{
-		session: session,
-		ctx:     ctx,
+		driver: driver,
+		ctx:    ctx,
 	}
 }
 
 func (n *Nodes) Set(node entities.Node) error {
-	return multiUpdate(
-		n.session,
+	return n.SetMany([]entities.Node{node})
+}
+
+func (n *Nodes) SetMany(nodes []entities.Node) error {
+	batch := make([]any, 0, len(nodes))
+	for _, node := range nodes {
+		var pool any = nil
+		if node.Links.MemberOfNodePoolUID != "" {
+			pool = node.Links.MemberOfNodePoolUID
+		}
+		batch = append(batch, map[string]any{
+			"uid":              node.UID,
+			"hostname":         node.Properties.Hostname,
+			"image":            node.Properties.Image,
+			"type":             node.Properties.Type,
+			"provider":         node.Properties.Provider,
+			"region":           node.Properties.Region,
+			"zone":             node.Properties.Zone,
+			"os":               node.Properties.OS,
+			"osImage":          node.Properties.OSImage,
+			"architecture":     node.Properties.Architecture,
+			"kernel":           node.Properties.Kernel,
+			"kubelet":          node.Properties.Kubelet,
+			"containerRuntime": node.Properties.ContainerRuntime,
+			"cpuCapacity":      node.Properties.Capacity.CPU,
+			"memoryCapacity":   node.Properties.Capacity.Memory,
+			"podsCapacity":     node.Properties.Capacity.Pods,
+			"link_cluster_uid": node.Links.PartOfClusterUID,
+			"link_pool_uid":    pool,
+		})
+	}
+	return multiUpdateMany(
+		n.driver,
 		n.ctx,
-		map[string]any{
-			"uid":      node.UID,
-			"hostname": node.Properties.Hostname,
-			"image":    node.Properties.Image,
-			"type":     node.Properties.Type,
-		},
+		batch,
 		`
-			MERGE (node:Node { _uid: $uid })
-			SET node = { _uid: $uid, hostname: $hostname, image: $image, type: $type }
+			UNWIND $batch AS row
+			MERGE (node:Node { _uid: row.uid })
+			SET node = {
+				_uid: row.uid,
+				hostname: row.hostname,
+				image: row.image,
+				type: row.type,
+				provider: row.provider,
+				region: row.region,
+				zone: row.zone,
+				os: row.os,
+				osImage: row.osImage,
+				architecture: row.architecture,
+				kernel: row.kernel,
+				kubelet: row.kubelet,
+				containerRuntime: row.containerRuntime,
+				cpuCapacity: row.cpuCapacity,
+				memoryCapacity: row.memoryCapacity,
+				podsCapacity: row.podsCapacity
+			}
+			RETURN id(node)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (node:Node { _uid: row.uid })
+			WITH row, node
+				MERGE (cluster:Cluster { _uid: row.link_cluster_uid })
+				WITH row, node, cluster
+					MERGE (node)-[:PartOf]->(cluster)
+					WITH row, node, cluster
+						MATCH (node)-[r:PartOf]->(other)
+						WHERE other._uid <> cluster._uid
+						DELETE r
+			RETURN id(node)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (node:Node { _uid: row.uid })-[r:MemberOf]->(other)
+			WHERE row.link_pool_uid IS NULL OR other._uid <> row.link_pool_uid
+			DELETE r
+			RETURN id(node)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (node:Node { _uid: row.uid })
+			WHERE row.link_pool_uid IS NOT NULL
+			WITH row, node
+				MERGE (pool:NodePool { _uid: row.link_pool_uid })
+				WITH row, node, pool
+					MERGE (node)-[:MemberOf]->(pool)
 			RETURN id(node)
 		`)
 }
 
+func (n *Nodes) Get(id entities.NodeUID) (*entities.Node, bool, error) {
+	node := &entities.Node{}
+	found, err := findSingleJson(
+		n.driver,
+		n.ctx,
+		map[string]any{
+			"uid": id,
+		},
+		`
+			MATCH (node:Node { _uid: $uid })
+			OPTIONAL MATCH (node)-[:MemberOf]->(pool:NodePool)
+			OPTIONAL MATCH (node)-[:PartOf]->(cluster:Cluster)
+			WITH {
+				uid: node._uid,
+				type: "Node",
+				properties: {
+					hostname: node.hostname,
+					image: node.image,
+					type: node.type,
+					provider: node.provider,
+					region: node.region,
+					zone: node.zone,
+					os: node.os,
+					osImage: node.osImage,
+					architecture: node.architecture,
+					kernel: node.kernel,
+					kubelet: node.kubelet,
+					containerRuntime: node.containerRuntime,
+					capacity: {
+						cpu: node.cpuCapacity,
+						memory: node.memoryCapacity,
+						pods: node.podsCapacity
+					}
+				},
+				links: {
+					partOf: cluster._uid,
+					memberOf: pool._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(entry) as json
+		`,
+		node)
+	return node, found, err
+}
+
 func (n *Nodes) List() ([]entities.Node, error) {
 	var nodes []entities.Node
 	return nodes, findAllJson(
-		n.session,
+		n.driver,
 		n.ctx,
 		`
 			MATCH (node:Node)
+			OPTIONAL MATCH (node)-[:MemberOf]->(pool:NodePool)
+			OPTIONAL MATCH (node)-[:PartOf]->(cluster:Cluster)
 			WITH {
 				uid: node._uid,
 				type: "Node",
 				properties: {
 					hostname: node.hostname,
 					image: node.image,
-					type: node.type
+					type: node.type,
+					provider: node.provider,
+					region: node.region,
+					zone: node.zone,
+					os: node.os,
+					osImage: node.osImage,
+					architecture: node.architecture,
+					kernel: node.kernel,
+					kubelet: node.kubelet,
+					containerRuntime: node.containerRuntime,
+					capacity: {
+						cpu: node.cpuCapacity,
+						memory: node.memoryCapacity,
+						pods: node.podsCapacity
+					}
+				},
+				links: {
+					partOf: cluster._uid,
+					memberOf: pool._uid
 				}
 			} as entry
 			RETURN apoc.convert.toJson(collect(entry)) as json
 		`,
 		&nodes)
 }
+
+func (n *Nodes) SetEvent(event entities.NodeEvent) error {
+	return n.SetManyEvents([]entities.NodeEvent{event})
+}
+
+func (n *Nodes) SetManyEvents(events []entities.NodeEvent) error {
+	batches := map[string][]any{}
+	for _, event := range events {
+		var ended any = nil
+		if event.Properties.Ended != nil {
+			ended = event.Properties.Ended.Format(time.RFC3339)
+		}
+		var reason, from, to any = nil, nil, nil
+		if event.Properties.Reason != "" {
+			reason = event.Properties.Reason
+		}
+		if event.Properties.From != "" {
+			from = event.Properties.From
+		}
+		if event.Properties.To != "" {
+			to = event.Properties.To
+		}
+		batches[event.Type] = append(batches[event.Type], map[string]any{
+			"uid":           event.UID,
+			"started":       event.Properties.Started.Format(time.RFC3339),
+			"ended":         ended,
+			"reason":        reason,
+			"from":          from,
+			"to":            to,
+			"link_node_uid": event.Links.HappenedToNodeUID,
+		})
+	}
+	for eventType, batch := range batches {
+		err := multiUpdateMany(
+			n.driver,
+			n.ctx,
+			batch,
+			`
+				UNWIND $batch AS row
+				MERGE (event:`+eventType+`:NodeEvent { _uid: row.uid })
+				SET event = { _uid: row.uid, started: datetime(row.started), ended: datetime(row.ended), reason: row.reason, from: row.from, to: row.to }
+				RETURN id(event)
+			`,
+			`
+				UNWIND $batch AS row
+				MATCH (event:NodeEvent { _uid: row.uid })
+				WITH row, event
+					MERGE (node:Node { _uid: row.link_node_uid })
+					WITH row, event, node
+						MERGE (event)-[:HappenedTo]->(node)
+						WITH row, event, node
+							MATCH (event)-[r:HappenedTo]->(other)
+							WHERE other._uid <> node._uid
+							DELETE r
+				RETURN id(event)
+			`)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Nodes) ListEvents() ([]entities.NodeEvent, error) {
+	var events []entities.NodeEvent
+	return events, findAllJson(
+		n.driver,
+		n.ctx,
+		`
+			MATCH (event:NodeEvent)-[:HappenedTo]->(node:Node)
+			WITH {
+				uid: event._uid,
+				type: apoc.coll.removeAll(labels(event), ["NodeEvent"])[0],
+				properties: {
+					started: toString(event.started),
+					ended: toString(event.ended),
+					reason: event.reason,
+					from: event.from,
+					to: event.to
+				},
+				links: {
+					happenedTo: node._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&events)
+}
+
+func (n *Nodes) ListOngoingEvents(id entities.NodeUID) ([]entities.NodeEvent, error) {
+	var events []entities.NodeEvent
+	return events, findAllJsonWith(
+		n.driver,
+		n.ctx,
+		map[string]any{
+			"uid": id,
+		},
+		`
+			MATCH (event:NodeEvent)-[:HappenedTo]->(node:Node { _uid: $uid })
+			WHERE event.ended IS NULL
+			WITH {
+				uid: event._uid,
+				type: apoc.coll.removeAll(labels(event), ["NodeEvent"])[0],
+				properties: {
+					started: toString(event.started),
+					ended: toString(event.ended),
+					reason: event.reason,
+					from: event.from,
+					to: event.to
+				},
+				links: {
+					happenedTo: node._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&events)
+}
+
+func (n *Nodes) SetPool(pool entities.NodePool) error {
+	return n.SetManyPools([]entities.NodePool{pool})
+}
+
+func (n *Nodes) SetManyPools(pools []entities.NodePool) error {
+	batch := make([]any, 0, len(pools))
+	for _, pool := range pools {
+		var mode, vmSize, minSize, maxSize any = nil, nil, nil, nil
+		if pool.Properties.Mode != "" {
+			mode = pool.Properties.Mode
+		}
+		if pool.Properties.VMSize != "" {
+			vmSize = pool.Properties.VMSize
+		}
+		if pool.Properties.MinSize != nil {
+			minSize = *pool.Properties.MinSize
+		}
+		if pool.Properties.MaxSize != nil {
+			maxSize = *pool.Properties.MaxSize
+		}
+		batch = append(batch, map[string]any{
+			"uid":      pool.UID,
+			"name":     pool.Properties.Name,
+			"provider": pool.Properties.Provider,
+			"mode":     mode,
+			"vmSize":   vmSize,
+			"minSize":  minSize,
+			"maxSize":  maxSize,
+		})
+	}
+	return multiUpdateMany(
+		n.driver,
+		n.ctx,
+		batch,
+		`
+			UNWIND $batch AS row
+			MERGE (pool:NodePool { _uid: row.uid })
+			SET pool = { _uid: row.uid, name: row.name, provider: row.provider, mode: row.mode, vmSize: row.vmSize, minSize: row.minSize, maxSize: row.maxSize }
+			RETURN id(pool)
+		`)
+}
+
+func (n *Nodes) ListPools() ([]entities.NodePool, error) {
+	var pools []entities.NodePool
+	return pools, findAllJson(
+		n.driver,
+		n.ctx,
+		`
+			MATCH (pool:NodePool)
+			WITH {
+				uid: pool._uid,
+				type: "NodePool",
+				properties: {
+					name: pool.name,
+					provider: pool.provider,
+					mode: pool.mode,
+					vmSize: pool.vmSize,
+					minSize: pool.minSize,
+					maxSize: pool.maxSize
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&pools)
+}
@@ -9,43 +9,65 @@ import (
 	"context"
 	"dolittle.io/fleet-observer/entities"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"time"
 )
 
 type Runtimes struct {
-	session neo4j.SessionWithContext
-	ctx     context.Context
+	driver neo4j.DriverWithContext
+	ctx    context.Context
 }
 
-func NewRuntimes(session neo4j.SessionWithContext, ctx context.Context) *Runtimes {
+func NewRuntimes(driver neo4j.DriverWithContext, ctx context.Context) *Runtimes {
 	return &Runtimes{
-		session: session,
-		ctx:     ctx,
+		driver: driver,
+		ctx:    ctx,
 	}
 }
 
 func (r *Runtimes) SetVersion(version entities.RuntimeVersion) error {
-	var prerelease any = nil
-	if version.Properties.Prerelease != "" {
-		prerelease = version.Properties.Prerelease
-	}
-	return multiUpdate(
-		r.session,
-		r.ctx,
-		map[string]any{
+	return r.SetManyVersions([]entities.RuntimeVersion{version})
+}
+
+func (r *Runtimes) SetManyVersions(versions []entities.RuntimeVersion) error {
+	batch := make([]any, 0, len(versions))
+	for _, version := range versions {
+		var prerelease any = nil
+		if version.Properties.Prerelease != "" {
+			prerelease = version.Properties.Prerelease
+		}
+		var build any = nil
+		if version.Properties.Build != "" {
+			build = version.Properties.Build
+		}
+		var released any = nil
+		if version.Properties.Released != nil {
+			released = version.Properties.Released.Format(time.RFC3339)
+		}
+		batch = append(batch, map[string]any{
 			"uid":        version.UID,
 			"major":      version.Properties.Major,
 			"minor":      version.Properties.Minor,
 			"patch":      version.Properties.Patch,
 			"prerelease": prerelease,
-		},
+			"build":      build,
+			"released":   released,
+		})
+	}
+	return multiUpdateMany(
+		r.driver,
+		r.ctx,
+		batch,
 		`
-			MERGE (version:RuntimeVersion { _uid: $uid })
+			UNWIND $batch AS row
+			MERGE (version:RuntimeVersion { _uid: row.uid })
 			SET version = {
-				_uid: $uid,
-				major: $major,
-				minor: $minor,
-				patch: $patch,
-				prerelease: $prerelease
+				_uid: row.uid,
+				major: row.major,
+				minor: row.minor,
+				patch: row.patch,
+				prerelease: row.prerelease,
+				build: row.build,
+				released: datetime(row.released)
 			}
 			RETURN id(version)
 		`)
@@ -54,7 +76,7 @@ func (r *Runtimes) SetVersion(version entities.RuntimeVersion) error {
 func (r *Runtimes) ListVersions() ([]entities.RuntimeVersion, error) {
 	var versions []entities.RuntimeVersion
 	return versions, findAllJson(
-		r.session,
+		r.driver,
 		r.ctx,
 		`
 			MATCH (version:RuntimeVersion)
@@ -65,7 +87,9 @@ func (r *Runtimes) ListVersions() ([]entities.RuntimeVersion, error) {
 					major: version.major,
 					minor: version.minor,
 					patch: version.patch,
-					prerelease: version.prerelease
+					prerelease: version.prerelease,
+					build: version.build,
+					released: toString(version.released)
 				}
 			} as entry
 			RETURN apoc.convert.toJson(collect(entry)) as json
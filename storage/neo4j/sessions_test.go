@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"fmt"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// standInDriver is a neo4j.DriverWithContext that records how sessions are used, instead of connecting to Neo4j.
+// Like the real driver, every write returns a new bookmark that is passed to the BookmarkManager of the session.
+// Only the methods used by the repositories are implemented.
+type standInDriver struct {
+	neo4j.DriverWithContext
+	t *testing.T
+
+	mu        sync.Mutex
+	sessions  []*standInSession
+	bookmarks int64
+	rows      int64
+}
+
+func newStandInDriver(t *testing.T) *standInDriver {
+	return &standInDriver{t: t}
+}
+
+func (d *standInDriver) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	session := &standInSession{driver: d, manager: config.BookmarkManager}
+	if config.BookmarkManager != nil {
+		given, err := config.BookmarkManager.GetBookmarks(ctx, config.DatabaseName)
+		if err != nil {
+			d.t.Errorf("failed to get bookmarks: %v", err)
+		}
+		session.given = given
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sessions = append(d.sessions, session)
+	return session
+}
+
+// standInSession records the BookmarkManager and bookmarks that it was given, and the bookmark of its last write
+type standInSession struct {
+	neo4j.SessionWithContext
+	driver  *standInDriver
+	manager neo4j.BookmarkManager
+	given   neo4j.Bookmarks
+	written string
+	inUse   int32
+	closed  int32
+}
+
+func (s *standInSession) ExecuteRead(_ context.Context, work neo4j.ManagedTransactionWork, _ ...func(*neo4j.TransactionConfig)) (any, error) {
+	return s.execute(work)
+}
+
+func (s *standInSession) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork, _ ...func(*neo4j.TransactionConfig)) (any, error) {
+	result, err := s.execute(work)
+	if err != nil {
+		return result, err
+	}
+
+	s.written = fmt.Sprintf("bookmark-%d", atomic.AddInt64(&s.driver.bookmarks, 1))
+	if s.manager != nil {
+		if err := s.manager.UpdateBookmarks(ctx, "", s.given, neo4j.Bookmarks{s.written}); err != nil {
+			s.driver.t.Errorf("failed to update bookmarks: %v", err)
+		}
+	}
+	return result, nil
+}
+
+func (s *standInSession) execute(work neo4j.ManagedTransactionWork) (any, error) {
+	if !atomic.CompareAndSwapInt32(&s.inUse, 0, 1) {
+		s.driver.t.Error("session was used concurrently")
+		return nil, fmt.Errorf("session was used concurrently")
+	}
+	defer atomic.StoreInt32(&s.inUse, 0)
+
+	if atomic.LoadInt32(&s.closed) == 1 {
+		s.driver.t.Error("session was used after it was closed")
+	}
+	return work(&standInTransaction{driver: s.driver})
+}
+
+func (s *standInSession) Close(_ context.Context) error {
+	if !atomic.CompareAndSwapInt32(&s.closed, 0, 1) {
+		s.driver.t.Error("session was closed twice")
+	}
+	return nil
+}
+
+type standInTransaction struct {
+	neo4j.ManagedTransaction
+	driver *standInDriver
+}
+
+func (t *standInTransaction) Run(_ context.Context, _ string, params map[string]any) (neo4j.ResultWithContext, error) {
+	if batch, ok := params["batch"].([]any); ok {
+		atomic.AddInt64(&t.driver.rows, int64(len(batch)))
+	}
+	return &standInResult{}, nil
+}
+
+type standInResult struct {
+	neo4j.ResultWithContext
+}
+
+func (r *standInResult) Next(_ context.Context) bool {
+	return false
+}
+
+func (r *standInResult) Err() error {
+	return nil
+}
+
+func (r *standInResult) Consume(_ context.Context) (neo4j.ResultSummary, error) {
+	return nil, nil
+}
+
+func (r *standInResult) Single(_ context.Context) (*neo4j.Record, error) {
+	return &neo4j.Record{Keys: []string{"json"}, Values: []any{"[]"}}, nil
+}
+
+// expectSessionsClosedWithSharedBookmarks checks that all the sessions were closed, and were given the same BookmarkManager
+func expectSessionsClosedWithSharedBookmarks(t *testing.T, standIn *standInDriver) {
+	t.Helper()
+
+	if len(standIn.sessions) == 0 {
+		t.Fatal("expected sessions to be used")
+	}
+	shared := standIn.sessions[0].manager
+	if shared == nil {
+		t.Error("expected sessions to use a bookmark manager")
+	}
+	for i, session := range standIn.sessions {
+		if session.manager != shared {
+			t.Errorf("expected session %d to share the bookmark manager of the other sessions", i)
+		}
+		if atomic.LoadInt32(&session.closed) != 1 {
+			t.Errorf("expected session %d to be closed", i)
+		}
+	}
+}
+
+func TestConcurrentWritesUseSeparateSessionsWithSharedBookmarks(t *testing.T) {
+	const writers = 32
+	const writes = 100
+
+	standIn := newStandInDriver(t)
+	driver := WithSharedBookmarks(standIn)
+	artifacts := NewArtifacts(driver, context.Background())
+	customers := NewCustomers(driver, context.Background())
+
+	var wg sync.WaitGroup
+	for writer := 0; writer < writers; writer++ {
+		wg.Add(1)
+		go func(writer int) {
+			defer wg.Done()
+			for write := 0; write < writes; write++ {
+				version := entities.NewArtifactVersion("customer", fmt.Sprintf("artifact-%d", writer), fmt.Sprintf("1.0.%d", write), nil)
+				if err := artifacts.SetManyVersions([]entities.ArtifactVersion{version, version}); err != nil {
+					t.Errorf("failed to set versions: %v", err)
+					return
+				}
+				customer := entities.NewCustomer(fmt.Sprintf("customer-%d-%d", writer, write), "Customer")
+				if err := customers.Set(customer); err != nil {
+					t.Errorf("failed to set customer: %v", err)
+					return
+				}
+				if _, _, err := artifacts.GetVersion(version.UID); err != nil {
+					t.Errorf("failed to get version: %v", err)
+					return
+				}
+				if _, err := customers.List(); err != nil {
+					t.Errorf("failed to list customers: %v", err)
+					return
+				}
+			}
+		}(writer)
+	}
+	wg.Wait()
+
+	if len(standIn.sessions) != writers*writes*4 {
+		t.Errorf("expected %d sessions, got %d", writers*writes*4, len(standIn.sessions))
+	}
+	if standIn.rows%(writers*writes) != 0 || standIn.rows < writers*writes*3 {
+		t.Errorf("expected a multiple of %d written rows, got %d", writers*writes, standIn.rows)
+	}
+	expectSessionsClosedWithSharedBookmarks(t, standIn)
+}
+
+func TestSessionsOfRepositoriesAreGivenTheBookmarksOfTheWritesOfEachOther(t *testing.T) {
+	standIn := newStandInDriver(t)
+	driver := WithSharedBookmarks(standIn)
+	artifacts := NewArtifacts(driver, context.Background())
+	customers := NewCustomers(driver, context.Background())
+
+	if err := artifacts.Set(entities.NewArtifact("customer", "artifact")); err != nil {
+		t.Fatal(err)
+	}
+	if err := customers.Set(entities.NewCustomer("customer", "Customer")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := artifacts.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectSessionsClosedWithSharedBookmarks(t, standIn)
+	if len(standIn.sessions) != 3 {
+		t.Fatalf("expected 3 sessions, got %d", len(standIn.sessions))
+	}
+	artifactWrite, customerWrite, artifactRead := standIn.sessions[0], standIn.sessions[1], standIn.sessions[2]
+	if len(artifactWrite.given) != 0 {
+		t.Errorf("expected the first session not to be given bookmarks, got %v", artifactWrite.given)
+	}
+	if len(customerWrite.given) != 1 || customerWrite.given[0] != artifactWrite.written {
+		t.Errorf("expected the customers session to be given the bookmark %v of the artifacts write, got %v", artifactWrite.written, customerWrite.given)
+	}
+	if len(artifactRead.given) != 1 || artifactRead.given[0] != customerWrite.written {
+		t.Errorf("expected the artifacts session to be given the bookmark %v of the customers write, got %v", customerWrite.written, artifactRead.given)
+	}
+}
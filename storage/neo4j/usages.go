@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"dolittle.io/fleet-observer/entities"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"time"
+)
+
+type Usages struct {
+	driver neo4j.DriverWithContext
+	ctx    context.Context
+}
+
+func NewUsages(driver neo4j.DriverWithContext, ctx context.Context) *Usages {
+	return &Usages{
+		driver: driver,
+		ctx:    ctx,
+	}
+}
+
+func (u *Usages) Set(usage entities.ResourceUsage) error {
+	return u.SetMany([]entities.ResourceUsage{usage})
+}
+
+func (u *Usages) SetMany(usages []entities.ResourceUsage) error {
+	batch := make([]any, 0, len(usages))
+	for _, usage := range usages {
+		batch = append(batch, map[string]any{
+			"uid":               usage.UID,
+			"container":         usage.Properties.Container,
+			"from":              usage.Properties.From.Format(time.RFC3339),
+			"to":                usage.Properties.To.Format(time.RFC3339),
+			"samples":           usage.Properties.Samples,
+			"cpuMin":            usage.Properties.CPU.Min,
+			"cpuAvg":            usage.Properties.CPU.Avg,
+			"cpuMax":            usage.Properties.CPU.Max,
+			"cpuP95":            usage.Properties.CPU.P95,
+			"memoryMin":         usage.Properties.Memory.Min,
+			"memoryAvg":         usage.Properties.Memory.Avg,
+			"memoryMax":         usage.Properties.Memory.Max,
+			"memoryP95":         usage.Properties.Memory.P95,
+			"link_instance_uid": usage.Links.MeasuredOnDeploymentInstanceUID,
+		})
+	}
+	return multiUpdateMany(
+		u.driver,
+		u.ctx,
+		batch,
+		`
+			UNWIND $batch AS row
+			MERGE (usage:ResourceUsage { _uid: row.uid })
+			SET usage = {
+				_uid: row.uid,
+				container: row.container,
+				from: datetime(row.from),
+				to: datetime(row.to),
+				samples: row.samples,
+				cpuMin: row.cpuMin,
+				cpuAvg: row.cpuAvg,
+				cpuMax: row.cpuMax,
+				cpuP95: row.cpuP95,
+				memoryMin: row.memoryMin,
+				memoryAvg: row.memoryAvg,
+				memoryMax: row.memoryMax,
+				memoryP95: row.memoryP95
+			}
+			RETURN id(usage)
+		`,
+		`
+			UNWIND $batch AS row
+			MATCH (usage:ResourceUsage { _uid: row.uid })
+			WITH row, usage
+				MERGE (instance:DeploymentInstance { _uid: row.link_instance_uid })
+				WITH row, usage, instance
+					MERGE (usage)-[:MeasuredOn]->(instance)
+					WITH row, usage, instance
+						MATCH (usage)-[r:MeasuredOn]->(other)
+						WHERE other._uid <> instance._uid
+						DELETE r
+			RETURN id(usage)
+		`)
+}
+
+func (u *Usages) List() ([]entities.ResourceUsage, error) {
+	var usages []entities.ResourceUsage
+	return usages, findAllJson(
+		u.driver,
+		u.ctx,
+		`
+			MATCH (usage:ResourceUsage)-[:MeasuredOn]->(instance:DeploymentInstance)
+			WITH {
+				uid: usage._uid,
+				type: "ResourceUsage",
+				properties: {
+					container: usage.container,
+					from: toString(usage.from),
+					to: toString(usage.to),
+					samples: usage.samples,
+					cpu: {
+						min: usage.cpuMin,
+						avg: usage.cpuAvg,
+						max: usage.cpuMax,
+						p95: usage.cpuP95
+					},
+					memory: {
+						min: usage.memoryMin,
+						avg: usage.memoryAvg,
+						max: usage.memoryMax,
+						p95: usage.memoryP95
+					}
+				},
+				links: {
+					measuredOn: instance._uid
+				}
+			} as entry
+			RETURN apoc.convert.toJson(collect(entry)) as json
+		`,
+		&usages)
+}
@@ -18,7 +18,16 @@ var (
 	ErrResultJsonFieldWasNotString    = errors.New("the field called 'json' in the resulting record was not a string")
 )
 
-func multiUpdate(session neo4j.SessionWithContext, ctx context.Context, params map[string]any, cyphers ...string) error {
+// multiUpdateMany runs the cyphers in a single transaction with the batch as the $batch parameter, the cyphers are expected to UNWIND the batch
+func multiUpdateMany(driver neo4j.DriverWithContext, ctx context.Context, batch []any, cyphers ...string) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	params := map[string]any{"batch": batch}
 	_, err := session.ExecuteWrite(
 		ctx,
 		func(transaction neo4j.ManagedTransaction) (any, error) {
@@ -39,42 +48,65 @@ func multiUpdate(session neo4j.SessionWithContext, ctx context.Context, params m
 	return err
 }
 
-func findSingleJson(session neo4j.SessionWithContext, ctx context.Context, params map[string]any, cypher string, v any) (bool, error) {
-	result, err := session.Run(ctx, cypher, params)
-	if err != nil {
-		return false, err
-	}
+func findSingleJson(driver neo4j.DriverWithContext, ctx context.Context, params map[string]any, cypher string, v any) (bool, error) {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
 
-	if !result.Next(ctx) {
-		return false, nil
-	}
+	record, err := session.ExecuteRead(
+		ctx,
+		func(transaction neo4j.ManagedTransaction) (any, error) {
+			result, err := transaction.Run(ctx, cypher, params)
+			if err != nil {
+				return nil, err
+			}
 
-	record := result.Record()
+			if !result.Next(ctx) {
+				return nil, result.Err()
+			}
 
-	foundMore := false
-	for result.Next(ctx) {
-		foundMore = true
-	}
+			record := result.Record()
+
+			foundMore := false
+			for result.Next(ctx) {
+				foundMore = true
+			}
 
-	if foundMore {
-		return false, ErrFoundMoreThanOneRecord
+			if foundMore {
+				return nil, ErrFoundMoreThanOneRecord
+			}
+
+			return record, nil
+		})
+	if err != nil || record == nil {
+		return false, err
 	}
 
-	return true, decodeRecordAsJson(record, v)
+	return true, decodeRecordAsJson(record.(*neo4j.Record), v)
 }
 
-func findAllJson(session neo4j.SessionWithContext, ctx context.Context, cypher string, v any) error {
-	result, err := session.Run(ctx, cypher, nil)
-	if err != nil {
-		return err
-	}
+func findAllJson(driver neo4j.DriverWithContext, ctx context.Context, cypher string, v any) error {
+	return findAllJsonWith(driver, ctx, nil, cypher, v)
+}
+
+func findAllJsonWith(driver neo4j.DriverWithContext, ctx context.Context, params map[string]any, cypher string, v any) error {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
 
-	record, err := result.Single(ctx)
+	record, err := session.ExecuteRead(
+		ctx,
+		func(transaction neo4j.ManagedTransaction) (any, error) {
+			result, err := transaction.Run(ctx, cypher, params)
+			if err != nil {
+				return nil, err
+			}
+
+			return result.Single(ctx)
+		})
 	if err != nil {
 		return err
 	}
 
-	return decodeRecordAsJson(record, v)
+	return decodeRecordAsJson(record.(*neo4j.Record), v)
 }
 
 func decodeRecordAsJson(record *neo4j.Record, v any) error {
@@ -9,5 +9,14 @@ import "dolittle.io/fleet-observer/entities"
 
 type Nodes interface {
 	Set(node entities.Node) error
+	SetMany(nodes []entities.Node) error
+	Get(id entities.NodeUID) (*entities.Node, bool, error)
 	List() ([]entities.Node, error)
+	SetEvent(event entities.NodeEvent) error
+	SetManyEvents(events []entities.NodeEvent) error
+	ListEvents() ([]entities.NodeEvent, error)
+	ListOngoingEvents(id entities.NodeUID) ([]entities.NodeEvent, error)
+	SetPool(pool entities.NodePool) error
+	SetManyPools(pools []entities.NodePool) error
+	ListPools() ([]entities.NodePool, error)
 }
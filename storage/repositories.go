@@ -6,6 +6,7 @@
 package storage
 
 type Repositories struct {
+	Clusters       Clusters
 	Nodes          Nodes
 	Customers      Customers
 	Applications   Applications
@@ -15,4 +16,8 @@ type Repositories struct {
 	Deployments    Deployments
 	Configurations Configurations
 	Events         Events
+	Usages         Usages
+	Images         Images
+	Endpoints      Endpoints
+	Autoscalers    Autoscalers
 }
@@ -9,5 +9,6 @@ import "dolittle.io/fleet-observer/entities"
 
 type Runtimes interface {
 	SetVersion(version entities.RuntimeVersion) error
+	SetManyVersions(versions []entities.RuntimeVersion) error
 	ListVersions() ([]entities.RuntimeVersion, error)
 }
@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) Dolittle. All rights reserved.
+ * Licensed under the MIT license. See LICENSE file in the project root for full license information.
+ */
+
+package storage
+
+import "dolittle.io/fleet-observer/entities"
+
+type Usages interface {
+	Set(usage entities.ResourceUsage) error
+	SetMany(usages []entities.ResourceUsage) error
+	List() ([]entities.ResourceUsage, error)
+}